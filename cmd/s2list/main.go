@@ -0,0 +1,181 @@
+// src/go/cmd/s2list/main.go   2026-10-17
+// Command-line tool for s2list lists which are persisted in files.
+/*-------------------------------------------------------------------------
+Functions in this program.
+
+usage
+run
+load
+main
+-------------------------------------------------------------------------*/
+
+/*
+The s2list command creates, modifies and inspects lists which are saved in
+files with List_base::SaveFile(), so that fixtures and checkpoints of programs
+can be examined outside the program. Values which are added on the command
+line are strings. Type "s2list -h" for a list of commands.
+*/
+package main
+
+import "flag"
+import "fmt"
+import "io"
+import "os"
+import "strings"
+
+import "github.com/drauk/s2list"
+
+const help_text = `Usage: s2list [-codec NAME] COMMAND FILE [VALUE...]
+Commands:
+    create FILE [VALUE...]  create a list with the values, replacing FILE
+    append FILE VALUE...    append values to a list
+    prepend FILE VALUE...   prepend values to a list
+    pop FILE                pop the first value of a list and show it
+    poplast FILE            pop the last value of a list and show it
+    length FILE             show the length of a list
+    validate FILE           check that FILE holds a sound list
+    dump FILE               show the values of a list, one per line
+    codecs                  show the names of the codecs
+Options:
+`
+
+/*
+usage() prints the help text to standard error.
+*/
+func usage() {
+    //----------------------//
+    //         usage        //
+    //----------------------//
+    fmt.Fprint(os.Stderr, help_text)
+    flag.PrintDefaults()
+}   // End of function usage.
+
+/*
+run() executes the command cmd with the arguments args, with lists in the
+format of the codec. The output goes to w.
+*/
+func run(w io.Writer, codec s2list.Codec, cmd string, args []string) error {
+    //----------------------//
+    //          run         //
+    //----------------------//
+    if cmd == "codecs" {
+        fmt.Fprintln(w, strings.Join(s2list.CodecNames(), "\n"))
+        return nil
+    }
+    if len(args) == 0 {
+        return fmt.Errorf("%s: missing file name", cmd)
+    }
+    path := args[0]
+    values := args[1:]
+    if cmd == "create" {
+        b := new(s2list.List_base)
+        for _, v := range values {
+            E := b.AppendValue(v)
+            if E != nil {
+                return E
+            }
+        }
+        return b.SaveFile(path, codec)
+    }
+    b, E := load(path, codec)
+    if E != nil {
+        return E
+    }
+    switch cmd {
+    case "append", "prepend":
+        if len(values) == 0 {
+            return fmt.Errorf("%s: missing values", cmd)
+        }
+        for _, v := range values {
+            if cmd == "append" {
+                E = b.AppendValue(v)
+            } else {
+                E = b.PrependValue(v)
+            }
+            if E != nil {
+                return E
+            }
+        }
+        return b.SaveFile(path, codec)
+    case "pop", "poplast":
+        var v interface{}
+        var ok bool
+        if cmd == "pop" {
+            v, ok, E = b.PopfirstValue()
+        } else {
+            v, ok, E = b.PoplastValue()
+        }
+        if E != nil {
+            return E
+        }
+        if !ok {
+            fmt.Fprintln(w, "(empty)")
+            return nil
+        }
+        fmt.Fprintln(w, v)
+        return b.SaveFile(path, codec)
+    case "length":
+        fmt.Fprintln(w, b.Length())
+    case "validate":
+        rep, E := b.Validate()
+        if E != nil {
+            return E
+        }
+        if !rep.OK() {
+            for _, d := range rep.Defects {
+                fmt.Fprintf(w, "%s at node %d\n", d.Kind, d.Index)
+            }
+            return fmt.Errorf("%s: %d defects", path, len(rep.Defects))
+        }
+        fmt.Fprintf(w, "ok: %d values\n", rep.Length)
+    case "dump":
+        for q, E := range b.All() {
+            if E != nil {
+                return E
+            }
+            v, _ := q.GetValue()
+            fmt.Fprintln(w, v)
+        }
+    default:
+        return fmt.Errorf("%s: unknown command", cmd)
+    }
+    return nil
+}   // End of function run.
+
+/*
+load() reads the list in the file at path.
+*/
+func load(path string, codec s2list.Codec) (*s2list.List_base, error) {
+    //----------------------//
+    //         load         //
+    //----------------------//
+    b, E := s2list.LoadFile(path, codec)
+    if E != nil {
+        return nil, E
+    }
+    b.SetName(path)
+    return b, nil
+}   // End of function load.
+
+func main() {
+    //----------------------//
+    //         main         //
+    //----------------------//
+    codec_name := flag.String("codec", "json", "the format of the files")
+    flag.Usage = usage
+    flag.Parse()
+    if flag.NArg() == 0 {
+        usage()
+        os.Exit(2)
+    }
+    codec, ok := s2list.LookupCodec(*codec_name)
+    if !ok {
+        fmt.Fprintf(os.Stderr, "s2list: unknown codec %q\n", *codec_name)
+        os.Exit(2)
+    }
+    E := run(os.Stdout, codec, flag.Arg(0), flag.Args()[1:])
+    if E != nil {
+        fmt.Fprintf(os.Stderr, "s2list: %v\n", E)
+        os.Exit(1)
+    }
+}   // End of function main.
@@ -0,0 +1,401 @@
+// src/go/cmd/s2listgen/main.go   2026-10-17
+// Generator of s2list lists which are specialized for one value type.
+/*-------------------------------------------------------------------------
+Functions in this program.
+
+listName
+typeWord
+generate
+main
+-------------------------------------------------------------------------*/
+
+/*
+The s2listgen command writes the Go source of a singly-linked list type whose
+values have one fixed type, such as int, instead of interface{}. The values are
+stored in the nodes without boxing, which halves the memory of lists of small
+values, and works without generics. The generated lists keep the base-pointers
+of s2list, so a node cannot be in two lists, and their errors are the error
+kinds of package s2list. It is meant for go:generate:
+    //go:generate s2listgen -type int
+    //go:generate s2listgen -type *Foo -name FooList
+    //go:generate s2listgen -type *bytes.Buffer -import bytes
+Type "s2listgen -h" for the options.
+*/
+package main
+
+import "bytes"
+import "flag"
+import "fmt"
+import "go/format"
+import "os"
+import "strings"
+import "text/template"
+import "unicode"
+
+/*
+list_template is the source of a generated list. The fields are those of
+gen_params.
+*/
+const list_template = `// Code generated by s2listgen -type {{.Type}} -name {{.Name}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import "fmt"
+{{range .Imports}}import "{{.}}"
+{{end}}
+import "github.com/drauk/s2list"
+
+// A {{.Name}}_node is an element of a {{.Name}}.
+type {{.Name}}_node struct {
+	next  *{{.Name}}_node // Next node in the list.
+	base  *{{.Name}}      // The list which the node is in, or nil.
+	value {{.Type}}       // The payload of the node.
+}
+
+// New{{.Name}}_node returns a new node with the value v, which is not in a list.
+func New{{.Name}}_node(v {{.Type}}) *{{.Name}}_node {
+	return &{{.Name}}_node{value: v}
+}
+
+// Next returns the next node in the list, or nil.
+func (p *{{.Name}}_node) Next() *{{.Name}}_node {
+	return p.next
+}
+
+// Value returns the value of the node.
+func (p *{{.Name}}_node) Value() {{.Type}} {
+	return p.value
+}
+
+// SetValue replaces the value of the node.
+func (p *{{.Name}}_node) SetValue(v {{.Type}}) {
+	p.value = v
+}
+
+// A {{.Name}} is a singly-linked list of {{.Type}} values. The zero value is an
+// empty list.
+type {{.Name}} struct {
+	first *{{.Name}}_node // First node of the list.
+	last  *{{.Name}}_node // Last node of the list.
+	n     int             // The number of nodes.
+}
+
+// Len returns the number of nodes in the list.
+func (p *{{.Name}}) Len() int {
+	return p.n
+}
+
+// Empty returns true if the list has no nodes.
+func (p *{{.Name}}) Empty() bool {
+	return p.first == nil
+}
+
+// Front returns the first node of the list, or nil.
+func (p *{{.Name}}) Front() *{{.Name}}_node {
+	return p.first
+}
+
+// Back returns the last node of the list, or nil.
+func (p *{{.Name}}) Back() *{{.Name}}_node {
+	return p.last
+}
+
+// Append appends the node q to the list. It fails if q is in a list.
+func (p *{{.Name}}) Append(q *{{.Name}}_node) error {
+	if q == nil {
+		return fmt.Errorf("%w: {{.Name}}.Append: q == nil", s2list.ErrNilArgument)
+	}
+	if q.base != nil {
+		return fmt.Errorf("%w: {{.Name}}.Append: q.base != nil", s2list.ErrNodeInOtherList)
+	}
+	q.base = p
+	q.next = nil
+	if p.last == nil {
+		p.first = q
+	} else {
+		p.last.next = q
+	}
+	p.last = q
+	p.n++
+	return nil
+}
+
+// AppendValue appends a new node with the value v to the list, and returns it.
+func (p *{{.Name}}) AppendValue(v {{.Type}}) *{{.Name}}_node {
+	q := &{{.Name}}_node{value: v}
+	p.Append(q)
+	return q
+}
+
+// Prepend inserts the node q at the front of the list. It fails if q is in a
+// list.
+func (p *{{.Name}}) Prepend(q *{{.Name}}_node) error {
+	if q == nil {
+		return fmt.Errorf("%w: {{.Name}}.Prepend: q == nil", s2list.ErrNilArgument)
+	}
+	if q.base != nil {
+		return fmt.Errorf("%w: {{.Name}}.Prepend: q.base != nil", s2list.ErrNodeInOtherList)
+	}
+	q.base = p
+	q.next = p.first
+	p.first = q
+	if p.last == nil {
+		p.last = q
+	}
+	p.n++
+	return nil
+}
+
+// PrependValue inserts a new node with the value v at the front of the list,
+// and returns it.
+func (p *{{.Name}}) PrependValue(v {{.Type}}) *{{.Name}}_node {
+	q := &{{.Name}}_node{value: v}
+	p.Prepend(q)
+	return q
+}
+
+// Popfirst removes the first node of the list and returns it, or returns nil if
+// the list is empty.
+func (p *{{.Name}}) Popfirst() *{{.Name}}_node {
+	q := p.first
+	if q == nil {
+		return nil
+	}
+	p.first = q.next
+	if p.first == nil {
+		p.last = nil
+	}
+	q.next = nil
+	q.base = nil
+	p.n--
+	return q
+}
+
+// PopfirstValue removes the first node of the list and returns its value and
+// true, or returns false if the list is empty.
+func (p *{{.Name}}) PopfirstValue() ({{.Type}}, bool) {
+	q := p.Popfirst()
+	if q == nil {
+		var zero {{.Type}}
+		return zero, false
+	}
+	return q.value, true
+}
+
+// Remove removes the node q from the list. It fails if q is not a member of
+// the list. This costs O(n), because the predecessor of q must be found.
+func (p *{{.Name}}) Remove(q *{{.Name}}_node) error {
+	if q == nil || q.base != p {
+		return fmt.Errorf("%w: {{.Name}}.Remove: q.base != p", s2list.ErrNotMember)
+	}
+	var prev *{{.Name}}_node
+	for r := p.first; r != q; r = r.next {
+		if r == nil {
+			return fmt.Errorf("%w: {{.Name}}.Remove: q not found", s2list.ErrCorruptList)
+		}
+		prev = r
+	}
+	if prev == nil {
+		p.first = q.next
+	} else {
+		prev.next = q.next
+	}
+	if p.last == q {
+		p.last = prev
+	}
+	q.next = nil
+	q.base = nil
+	p.n--
+	return nil
+}
+
+// Clear removes all nodes from the list.
+func (p *{{.Name}}) Clear() {
+	for q := p.first; q != nil; {
+		r := q.next
+		q.next = nil
+		q.base = nil
+		q = r
+	}
+	p.first = nil
+	p.last = nil
+	p.n = 0
+}
+
+// Each calls f with the values of the list in order, until f returns false.
+func (p *{{.Name}}) Each(f func(v {{.Type}}) bool) {
+	for q := p.first; q != nil; q = q.next {
+		if !f(q.value) {
+			return
+		}
+	}
+}
+
+// Values returns the values of the list in order.
+func (p *{{.Name}}) Values() []{{.Type}} {
+	values := make([]{{.Type}}, 0, p.n)
+	for q := p.first; q != nil; q = q.next {
+		values = append(values, q.value)
+	}
+	return values
+}
+`
+
+/*
+The parameters of list_template.
+    Package string   // The package of the generated file.
+    Imports []string // The packages which the value type needs.
+    Type    string   // The value type.
+    Name    string   // The name of the list type.
+*/
+type gen_params struct {
+    Package string   // The package of the generated file.
+    Imports []string // The packages which the value type needs.
+    Type    string   // The value type.
+    Name    string   // The name of the list type.
+}
+
+/*
+listName() returns the default name of the list type for values of type t,
+such as IntList for int, FooList for *pkg.Foo, SliceByteList for []byte,
+Array4IntList for [4]int, MapStringIntList for map[string]int, ChanIntList for
+chan int and FuncList for func(). An error is returned for other types, such as
+functions with parameters, struct and interface literals, and generic types,
+which need the option -name.
+*/
+func listName(t string) (string, error) {
+    //----------------------//
+    //       listName       //
+    //----------------------//
+    w, ok := typeWord(strings.TrimSpace(t))
+    if !ok {
+        return "", fmt.Errorf("cannot derive a list type name from %q; use -name", t)
+    }
+    return w + "List", nil
+}   // End of function listName.
+
+/*
+typeWord() returns the part of the name of a list type which stands for the
+type t, and whether such a part could be derived. See listName().
+*/
+func typeWord(t string) (string, bool) {
+    //----------------------//
+    //       typeWord       //
+    //----------------------//
+    switch {
+    case strings.HasPrefix(t, "*"):
+        return typeWord(strings.TrimSpace(t[1:]))
+    case strings.HasPrefix(t, "[]"):
+        w, ok := typeWord(strings.TrimSpace(t[2:]))
+        return "Slice" + w, ok
+    case strings.HasPrefix(t, "["):
+        i := strings.Index(t, "]")
+        if i < 2 || strings.Trim(t[1:i], "0123456789") != "" {
+            return "", false
+        }
+        w, ok := typeWord(strings.TrimSpace(t[i + 1:]))
+        return "Array" + t[1:i] + w, ok
+    case strings.HasPrefix(t, "map["):
+        // Find the bracket which closes the key type.
+        depth := 0
+        for i := 3; i < len(t); i += 1 {
+            if t[i] == '[' {
+                depth += 1
+            } else if t[i] == ']' {
+                depth -= 1
+                if depth == 0 {
+                    k, ok := typeWord(strings.TrimSpace(t[4:i]))
+                    if !ok {
+                        return "", false
+                    }
+                    v, ok := typeWord(strings.TrimSpace(t[i + 1:]))
+                    return "Map" + k + v, ok
+                }
+            }
+        }
+        return "", false
+    case strings.HasPrefix(t, "chan "):
+        w, ok := typeWord(strings.TrimSpace(t[5:]))
+        return "Chan" + w, ok
+    case t == "func()":
+        return "Func", true
+    }
+    if i := strings.LastIndex(t, "."); i >= 0 {
+        t = t[i + 1:]
+    }
+    r := []rune(t)
+    if len(r) == 0 || !unicode.IsLetter(r[0]) && r[0] != '_' {
+        return "", false
+    }
+    for _, c := range r {
+        if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+            return "", false
+        }
+    }
+    r[0] = unicode.ToUpper(r[0])
+    return string(r), true
+}   // End of function typeWord.
+
+/*
+generate() returns the formatted source of the list for the parameters.
+*/
+func generate(params gen_params) ([]byte, error) {
+    //----------------------//
+    //       generate       //
+    //----------------------//
+    tmpl, E := template.New("list").Parse(list_template)
+    if E != nil {
+        return nil, E
+    }
+    var buf bytes.Buffer
+    E = tmpl.Execute(&buf, params)
+    if E != nil {
+        return nil, E
+    }
+    return format.Source(buf.Bytes())
+}   // End of function generate.
+
+func main() {
+    //----------------------//
+    //         main         //
+    //----------------------//
+    type_name := flag.String("type", "", "the value type, such as int or *Foo (required)")
+    list_name := flag.String("name", "", "the name of the list type (default: TypeList)")
+    pkg_name := flag.String("package", os.Getenv("GOPACKAGE"),
+        "the package of the generated file (default: $GOPACKAGE)")
+    imports := flag.String("import", "",
+        "comma-separated import paths which the type needs, such as bytes")
+    out_name := flag.String("o", "", "the output file (default: lowercase name + \"_s2list.go\")")
+    flag.Parse()
+    if *type_name == "" || *pkg_name == "" || flag.NArg() != 0 {
+        fmt.Fprintln(os.Stderr,
+            "Usage: s2listgen -type T [-name NAME] [-import PATHS] [-package PKG] [-o FILE]")
+        flag.PrintDefaults()
+        os.Exit(2)
+    }
+    params := gen_params{Package: *pkg_name, Type: *type_name, Name: *list_name}
+    if params.Name == "" {
+        name, E := listName(params.Type)
+        if E != nil {
+            fmt.Fprintf(os.Stderr, "s2listgen: %v\n", E)
+            os.Exit(2)
+        }
+        params.Name = name
+    }
+    if *imports != "" {
+        params.Imports = strings.Split(*imports, ",")
+    }
+    if *out_name == "" {
+        *out_name = strings.ToLower(params.Name) + "_s2list.go"
+    }
+    src, E := generate(params)
+    if E != nil {
+        fmt.Fprintf(os.Stderr, "s2listgen: %v\n", E)
+        os.Exit(1)
+    }
+    E = os.WriteFile(*out_name, src, 0644)
+    if E != nil {
+        fmt.Fprintf(os.Stderr, "s2listgen: %v\n", E)
+        os.Exit(1)
+    }
+}   // End of function main.
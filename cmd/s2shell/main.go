@@ -0,0 +1,281 @@
+// src/go/cmd/s2shell/main.go   2026-10-17
+// Interactive shell for experimenting with s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this program.
+
+main
+shell::
+shell::list
+shell::run
+dump
+dot
+-------------------------------------------------------------------------*/
+
+/*
+The s2shell command is an interactive teaching tool for the s2list package.
+It reads commands from standard input and applies them to named in-memory lists.
+Type "help" for a list of commands.
+*/
+package main
+
+import "bufio"
+import "fmt"
+import "io"
+import "os"
+import "sort"
+import "strconv"
+import "strings"
+
+import "github.com/drauk/s2list"
+
+const help_text = `Commands:
+    lists                   show the names of all lists
+    append LIST VALUE...    append values to a list
+    prepend LIST VALUE...   prepend values to a list
+    insert LIST N VALUE...  insert values after the first N nodes of a list
+    sort LIST               sort the values of a list as strings
+    pop LIST                pop the first node of a list
+    poplast LIST            pop the last node of a list
+    length LIST             show the length of a list
+    valid LIST              show the (nil, wrong, total) base-pointer counts
+    snapshot LIST NEW       make NEW a read-only snapshot of LIST
+    clear LIST              remove all nodes from a list
+    dump LIST               show the values of a list
+    dot LIST                show a list in Graphviz "dot" format
+    help                    show this text
+    quit                    leave the shell
+Lists are created when they are first named.
+`
+
+/*
+A shell holds the named lists of an interactive session.
+*/
+type shell struct {
+    lists map[string]*s2list.List_base // The lists, by name.
+    out   io.Writer                    // Where the output goes.
+}
+
+/*
+shell::list() returns the list with the given name, creating it if necessary.
+*/
+func (p *shell) list(name string) *s2list.List_base {
+    //----------------------//
+    //     shell::list      //
+    //----------------------//
+    b, ok := p.lists[name]
+    if !ok {
+        b = new(s2list.List_base)
+        b.SetName(name)
+        p.lists[name] = b
+    }
+    return b
+}   // End of function shell::list.
+
+/*
+shell::run() executes a single command line. The return value is false if the
+shell should exit.
+*/
+func (p *shell) run(line string) bool {
+    //----------------------//
+    //      shell::run      //
+    //----------------------//
+    words := strings.Fields(line)
+    if len(words) == 0 {
+        return true
+    }
+    cmd := words[0]
+    args := words[1:]
+    var E error
+    switch cmd {
+    case "quit", "exit":
+        return false
+    case "help", "?":
+        fmt.Fprint(p.out, help_text)
+        return true
+    case "lists":
+        var names []string
+        for name := range p.lists {
+            names = append(names, name)
+        }
+        sort.Strings(names)
+        for _, name := range names {
+            fmt.Fprintf(p.out, "%s\t%d\n", name, p.lists[name].Length())
+        }
+        return true
+    }
+    if len(args) == 0 {
+        fmt.Fprintf(p.out, "%s: missing list name. Type \"help\".\n", cmd)
+        return true
+    }
+    b := p.list(args[0])
+    switch cmd {
+    case "append":
+        for _, v := range args[1:] {
+            E = b.AppendValue(v)
+            if E != nil {
+                break
+            }
+        }
+    case "prepend":
+        for _, v := range args[1:] {
+            E = b.PrependValue(v)
+            if E != nil {
+                break
+            }
+        }
+    case "insert":
+        if len(args) < 2 {
+            fmt.Fprintln(p.out, "insert: missing position")
+            return true
+        }
+        pos, E2 := strconv.Atoi(args[1])
+        if E2 != nil || pos < 0 {
+            fmt.Fprintf(p.out, "insert: bad position %q\n", args[1])
+            return true
+        }
+        var it s2list.List_iter
+        it.Init(b)
+        for i := 0; i < pos && E == nil; i += 1 {
+            var q *s2list.List_node
+            q, E = it.Next()
+            if E == nil && q == nil {
+                fmt.Fprintf(p.out, "insert: the list has fewer than %d nodes\n", pos)
+                return true
+            }
+        }
+        for _, v := range args[2:] {
+            if E != nil {
+                break
+            }
+            E = it.InsertAfterCurrent(v)
+        }
+    case "sort":
+        var sa *s2list.Sort_adapter
+        sa, E = b.SortAdapter(func(x, y interface{}) bool {
+            return fmt.Sprint(x) < fmt.Sprint(y)
+        })
+        if E == nil {
+            sort.Stable(sa)
+            E = sa.Apply()
+        }
+    case "pop", "poplast":
+        var q *s2list.List_node
+        if cmd == "pop" {
+            q, E = b.Popfirst()
+        } else {
+            q, E = b.Poplast()
+        }
+        if E == nil {
+            if q == nil {
+                fmt.Fprintln(p.out, "(empty)")
+            } else {
+                v, _ := q.GetValue()
+                fmt.Fprintln(p.out, v)
+            }
+        }
+    case "length":
+        fmt.Fprintln(p.out, b.Length())
+    case "valid":
+        n_nil, n_wrong, n_total := b.ValidLength()
+        fmt.Fprintf(p.out, "nil=%d wrong=%d total=%d\n", n_nil, n_wrong, n_total)
+    case "snapshot":
+        if len(args) < 2 {
+            fmt.Fprintln(p.out, "snapshot: missing name of new list")
+            return true
+        }
+        s := b.Snapshot()
+        p.lists[args[1]] = s
+    case "clear":
+        E = b.Clear()
+    case "dump":
+        E = dump(p.out, b)
+    case "dot":
+        E = dot(p.out, args[0], b)
+    default:
+        fmt.Fprintf(p.out, "%s: unknown command. Type \"help\".\n", cmd)
+    }
+    if E != nil {
+        fmt.Fprintf(p.out, "error: %v\n", E)
+    }
+    return true
+}   // End of function shell::run.
+
+/*
+dump() prints the values of a list on a single line.
+*/
+func dump(w io.Writer, b *s2list.List_base) error {
+    //----------------------//
+    //         dump         //
+    //----------------------//
+    var it s2list.List_iter
+    it.Init(b)
+    fmt.Fprint(w, "[")
+    for i := 0; ; i += 1 {
+        q, E := it.Next()
+        if E != nil {
+            fmt.Fprintln(w, "]")
+            return E
+        }
+        if q == nil {
+            break
+        }
+        v, _ := q.GetValue()
+        if i > 0 {
+            fmt.Fprint(w, " ")
+        }
+        fmt.Fprint(w, v)
+    }
+    fmt.Fprintln(w, "]")
+    return nil
+}   // End of function dump.
+
+/*
+dot() prints a list as a Graphviz digraph, with the list-base as the first node
+and an edge for every next-pointer.
+*/
+func dot(w io.Writer, name string, b *s2list.List_base) error {
+    //----------------------//
+    //          dot         //
+    //----------------------//
+    var it s2list.List_iter
+    it.Init(b)
+    fmt.Fprintf(w, "digraph %q {\n", name)
+    fmt.Fprintf(w, "    base [shape=box, label=%q];\n", name)
+    prev := "base"
+    for i := 0; ; i += 1 {
+        q, E := it.Next()
+        if E != nil {
+            fmt.Fprintln(w, "}")
+            return E
+        }
+        if q == nil {
+            break
+        }
+        v, _ := q.GetValue()
+        node := fmt.Sprintf("n%d", i)
+        fmt.Fprintf(w, "    %s [label=%q];\n", node, fmt.Sprint(v))
+        fmt.Fprintf(w, "    %s -> %s;\n", prev, node)
+        prev = node
+    }
+    fmt.Fprintln(w, "}")
+    return nil
+}   // End of function dot.
+
+func main() {
+    //----------------------//
+    //         main         //
+    //----------------------//
+    sh := &shell{lists: make(map[string]*s2list.List_base), out: os.Stdout}
+    in := bufio.NewScanner(os.Stdin)
+    fmt.Fprintln(sh.out, "s2shell. Type \"help\" for a list of commands.")
+    for {
+        fmt.Fprint(sh.out, "s2> ")
+        if !in.Scan() {
+            break
+        }
+        if !sh.run(in.Text()) {
+            break
+        }
+    }
+    fmt.Fprintln(sh.out)
+}   // End of function main.
@@ -10,6 +10,9 @@ List_node::GetNext
 List_node::unlink
 List_node::SetValue
 List_node::GetValue
+List_node::Pin
+List_node::Unpin
+List_node::Pinned
 - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
 List_base::
 List_base::Empty
@@ -22,9 +25,26 @@ List_base::Prepend
 List_base::PrependValue
 List_base::Popfirst
 List_base::Poplast
+List_base::PopfirstValue
+List_base::PoplastValue
+List_base::FirstValue
+List_base::LastValue
+List_base::CountNilValues
 List_base::Found
 List_base::Remove
 List_base::Clear
+List_base::ModCount
+List_base::modify
+List_base::insertAfter
+List_base::removeAfter
+List_base::joining
+List_base::joined
+List_base::left
+List_base::reportRemove
+List_base::predsValid
+List_base::indexPreds
+List_base::All
+List_base::ReverseAll
 - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
 List_iter::
 List_iter::Init
@@ -32,6 +52,14 @@ List_iter::Restart
 List_iter::ItemCount
 List_iter::ItemCountValid
 List_iter::Next
+List_iter::RemoveCurrent
+List_iter::InsertAfterCurrent
+List_iter::Prev
+List_iter::loadChain
+List_iter::Peek
+List_iter::Skip
+List_iter::Seek
+List_iter::NextIndexed
 -------------------------------------------------------------------------*/
 
 /*
@@ -46,12 +74,13 @@ package s2list
 // import "fmt"
 // import "io"
 // import "log"
-// import "time"
-// import "errors"
+import "iter"
+import "reflect"
+import "sync/atomic"
+import "time"
+import "errors"
 // import "net/http"
 
-import "github.com/drauk/elist"
-
 //=============================================================================
 //=============================================================================
 
@@ -63,6 +92,8 @@ set to nil.
     next *List_node // Next node in a singly linked list.
     base *List_base // The base in which this object is listed.
     value interface{} // The payload of the list node.
+    pins  int32       // Number of List_node::Pin() calls not yet unpinned.
+    meta  *Node_meta  // Optional metadata. See List_base::EnableMeta().
 */
 type List_node struct {
     //----------------------//
@@ -74,7 +105,8 @@ type List_node struct {
       and it points to the list if it is a member of it.
       There is almost no control over what goes into the "value" field.
       Any attempt to make a list homogeneous can be easily defeated by a user
-      calling List_node::SetValue().
+      calling List_node::SetValue(), unless the owner of the list restricts
+      its values with List_base::SetElementType().
       However, if a client class keeps the list and nodes private, other users
       should not be able to invalidate the homogeneity of the list.
       ------------------------------------------------------------------------------*/
@@ -82,6 +114,8 @@ type List_node struct {
     base *List_base // The base in which this object is listed.
 
     value interface{} // The payload of the list node.
+    pins  int32       // Number of List_node::Pin() calls not yet unpinned.
+    meta  *Node_meta  // Optional metadata. See List_base::EnableMeta().
 }
 
 /*
@@ -89,13 +123,18 @@ List_node::GetNext() returns the next-field of the node. This gives read-only
 access to address of the next node in the list, if the node is within a list.
 The return value is nil if the node is outside any list, or it is the last
 element of its container-list.
+If the node was removed from a list in debug mode, ErrUseAfterRemove is
+returned instead. See List_base::SetDebug().
 */
 func (p *List_node) GetNext() (*List_node, error) {
     //----------------------//
     //  List_node::GetNext  //
     //----------------------//
     if p == nil {
-        return nil, elist.New("List_node::GetNext: p == nil")
+        return nil, newError(ErrNilReceiver, "List_node::GetNext: p == nil")
+    }
+    if p.next == poison_node {
+        return nil, ErrUseAfterRemove
     }
     return p.next, nil
 }   // End of function List_node::GetNext.
@@ -104,22 +143,29 @@ func (p *List_node) GetNext() (*List_node, error) {
 List_node::unlink() is a private member function for internal use in this
 package.
 This should only be called when a node is popped/removed/cleared from a list.
-The value payload is unaffected.
+The value payload is unaffected. In debug mode, the next-pointer is poisoned.
 */
 func (p *List_node) unlink() error {
     //----------------------//
     //   List_node::unlink  //
     //----------------------//
     if p == nil {
-        return elist.New("List_node::unlink: p == nil")
+        return newError(ErrNilReceiver, "List_node::unlink: p == nil")
+    }
+    if p.base != nil && len(p.base.cursors) > 0 {
+        p.base.leaving(p)
     }
     p.next = nil
+    if p.base != nil && p.base.debugging() {
+        p.next = poison_node
+    }
     p.base = nil
     return nil
 }   // End of function List_node::unlink.
 
 /*
 List_node::SetValue() clobbers whatever was in the "value" field before.
+An error is returned if the value is pinned. See List_node::Pin().
 */
 func (p *List_node) SetValue(v interface{}) error {
     //----------------------//
@@ -131,7 +177,23 @@ func (p *List_node) SetValue(v interface{}) error {
       and the value which you copy into the "value" field.
       ------------------------------------------------------------------------------*/
     if p == nil {
-        return elist.New("List_node::SetValue: p == nil")
+        return newError(ErrNilReceiver, "List_node::SetValue: p == nil")
+    }
+    if atomic.LoadInt32(&p.pins) > 0 {
+        return newError(ErrPinned, "List_node::SetValue: value is pinned")
+    }
+    // A value in a read-only list must not change.
+    if p.base != nil {
+        E := p.base.writable("List_node::SetValue")
+        if E != nil {
+            return E
+        }
+        if p.base.elem_type != nil || p.base.validator != nil {
+            E = p.base.checkValue("List_node::SetValue", p, v)
+            if E != nil {
+                return E
+            }
+        }
     }
     p.value = v
     return nil
@@ -149,11 +211,64 @@ func (p *List_node) GetValue() (interface{}, error) {
       Does this return a simple copy of bits in the value member?
       ------------------------------------------------------------------------------*/
     if p == nil {
-        return nil, elist.New("List_node::GetValue: p == nil")
+        return nil, newError(ErrNilReceiver, "List_node::GetValue: p == nil")
     }
     return p.value, nil
 }   // End of function List_node::GetValue.
 
+/*
+List_node::Pin() increments the pin count of the node. While the pin count is
+positive, List_node::SetValue() refuses to overwrite the value. This protects a
+payload which is shared between a producer and slower consumers. Every Pin-call
+must be matched by an Unpin-call. The pin count is safe for concurrent use.
+*/
+func (p *List_node) Pin() error {
+    //----------------------//
+    //    List_node::Pin    //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_node::Pin: p == nil")
+    }
+    atomic.AddInt32(&p.pins, 1)
+    return nil
+}   // End of function List_node::Pin.
+
+/*
+List_node::Unpin() decrements the pin count of the node. An error is returned if
+the node is not pinned.
+*/
+func (p *List_node) Unpin() error {
+    //----------------------//
+    //   List_node::Unpin   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_node::Unpin: p == nil")
+    }
+    for {
+        n := atomic.LoadInt32(&p.pins)
+        if n <= 0 {
+            return newError(ErrInvalidArgument, "List_node::Unpin: node is not pinned")
+        }
+        if atomic.CompareAndSwapInt32(&p.pins, n, n-1) {
+            return nil
+        }
+    }
+}   // End of function List_node::Unpin.
+
+/*
+List_node::Pinned() returns true if the pin count of the node is positive.
+*/
+func (p *List_node) Pinned() bool {
+    //----------------------//
+    //   List_node::Pinned  //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_node::Pinned")
+        return false
+    }
+    return atomic.LoadInt32(&p.pins) > 0
+}   // End of function List_node::Pinned.
+
 //=============================================================================
 //=============================================================================
 
@@ -177,6 +292,88 @@ type List_base struct {
       ------------------------------------------------------------------------------*/
     first *List_node // First node of the list.
     last  *List_node // Last node of the list.
+
+    // Snapshot support. See List_base::Snapshot().
+    origin *List_base // For a snapshot, the list it was taken from.
+
+    // Instrumentation. See List_base::SetSlowHook().
+    name string     // Name of the list for diagnostics.
+    slow *slow_hook // Reporting of slow operations, if non-nil.
+
+    // Incremented by every structural change. See List_base::ModCount().
+    modcount uint64
+
+    // Cursors which are registered with the list. See List_base::NewCursor().
+    cursors map[*Cursor]bool
+
+    // Pre-allocated nodes. See List_base::Reserve().
+    pool []*List_node
+
+    // Handling of detected corruption. See List_base::SetCorruptionPolicy().
+    corruption Corruption_policy
+    logf       func(string)
+
+    // Per-node metadata. See List_base::EnableMeta().
+    meta_on     bool   // Stamp inserted nodes with metadata.
+    meta_origin string // The origin tag for inserted nodes.
+    meta_seq    uint64 // The last sequence number which was assigned.
+    meta_popped uint64      // The highest sequence number which was popped.
+    meta_gaps   []Seq_range // Sequence numbers which have not been popped.
+    meta_lat    []uint64    // Dequeue latency histogram. See AgeStats().
+
+    // Retry limit. See List_base::SetMaxAttempts().
+    max_attempts int        // Maximum number of requeues, if positive.
+    dead_letter  *List_base // Where nodes go when they exceed the maximum.
+
+    // Self-organizing heuristic. See List_base::SetAccessMode().
+    access Access_mode
+
+    // Shared instances of values. See List_base::SetInterner().
+    interner *Interner
+
+    // Debug mode. See List_base::SetDebug().
+    debug     bool   // Verify the invariant before every mutation.
+    last_site string // The last mutation and its call site.
+
+    // Validation effort of the basic operations. See List_base::SetCheckLevel().
+    check Check_level
+
+    // Release of removed values. See List_base::SetRelease().
+    release *release_state
+
+    // Predecessor index, valid while pred_mod == modcount. See List_base::Remove().
+    preds    map[*List_node]*List_node
+    pred_mod uint64
+
+    // Change notifications. See List_base::Watch().
+    watchers []*watcher
+
+    // Mutation hooks. See List_base::SetHooks().
+    hooks *Hooks
+
+    // Operation counters. See List_base::EnableStats().
+    stats *list_stats
+
+    // Length limit. See List_base::SetMaxLen().
+    max_len  int               // The maximum length, if positive.
+    on_evict func(*List_node)  // Receives the evicted nodes.
+    n_len    int               // The length, valid while len_mod == modcount.
+    len_mod  uint64
+
+    // Availability token. See List_base::NotifyChan().
+    ready chan struct{}
+
+    // True if the mutating methods must refuse to modify the list.
+    readonly bool
+
+    // True after List_base::Freeze(). Unlike readonly, this is permanent.
+    frozen bool
+
+    // Type of the values. See List_base::SetElementType().
+    elem_type reflect.Type
+
+    // Domain rules for the values. See List_base::SetValidator().
+    validator func(interface{}) error
 }
 
 /*
@@ -188,6 +385,7 @@ func (p *List_base) Empty() bool {
     //   List_base::Empty   //
     //----------------------//
     if p == nil {
+        nilReceiver("List_base::Empty")
         return true
     }
     if p.first == nil {
@@ -206,6 +404,7 @@ func (p *List_base) GetFirst() *List_node {
     //  List_base::GetFirst //
     //----------------------//
     if p == nil {
+        nilReceiver("List_base::GetFirst")
         return nil
     }
     return p.first
@@ -221,14 +420,21 @@ func (p *List_base) Length() int {
     //   List_base::Length  //
     //----------------------//
     if p == nil {
+        nilReceiver("List_base::Length")
         return 0
     }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Length", time.Now())
+    }
     var n int = 0
     if p.first != nil && p.last != nil {
         for q := p.first; q != nil; q = q.next {
             n += 1
         }
     }
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.steps, uint64(n))
+    }
     return n
 }   // End of function List_base::Length.
 
@@ -248,8 +454,12 @@ func (p *List_base) ValidLength() (int, int, int) {
     //  List_base::ValidLength  //
     //--------------------------//
     if p == nil {
+        nilReceiver("List_base::ValidLength")
         return 0, 0, 0
     }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::ValidLength", time.Now())
+    }
     var n_nil, n_wrong, n_total int
     if p.first == nil || p.last == nil {
         return 0, 0, 0
@@ -276,23 +486,59 @@ func (p *List_base) Append(pnode *List_node) error {
     //   List_base::Append  //
     //----------------------//
     if p == nil {
-        return elist.New("List_base::Append: p == nil")
+        return newError(ErrNilReceiver, "List_base::Append: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Append", time.Now())
     }
     if pnode == nil {
         return nil
     }
     // Can't put an object in multiple lists.
-    if pnode.base != nil {
-        return elist.New("List_base::Append: pnode.base != nil")
+    if p.check != Check_off && pnode.base != nil {
+        return p.fail(ErrNodeInOtherList, pnode, "List_base::Append: pnode.base != nil")
+    }
+    if p.check == Check_full {
+        E := p.fullCheck("List_base::Append")
+        if E != nil {
+            return E
+        }
+    }
+    if p.elem_type != nil || p.validator != nil {
+        E := p.checkValue("List_base::Append", pnode, pnode.value)
+        if E != nil {
+            return E
+        }
+    }
+    indexed := p.predsValid()
+    counted := p.lenValid()
+    E := p.modify("List_base::Append")
+    if E != nil {
+        return E
     }
     pnode.base = p // Register the node with this list-base.
+    p.joining(pnode)
     pnode.next = nil
+    if indexed {
+        p.preds[pnode] = p.last
+        p.pred_mod = p.modcount
+    }
     if p.last != nil {
         p.last.next = pnode
     } else {
         p.first = pnode
+        if p.ready != nil {
+            p.filled()
+        }
     }
     p.last = pnode
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.appends, 1)
+    }
+    p.joined(pnode, Change_append, nil)
+    if p.max_len > 0 {
+        return p.trim("List_base::Append", counted, true)
+    }
     return nil
 }   // End of function List_base::Append.
 
@@ -306,18 +552,14 @@ func (p *List_base) AppendValue(v interface{}) error {
     //  List_base::AppendValue  //
     //--------------------------//
     if p == nil {
-        return elist.New("List_base::AppendValue: p == nil")
+        return newError(ErrNilReceiver, "List_base::AppendValue: p == nil")
     }
-    var pnode *List_node = new(List_node)
+    var pnode *List_node = p.newNode(v)
     var E error
 
-    E = pnode.SetValue(v)
-    if E != nil {
-        return elist.Push(E, "List_base::AppendValue: pnode.SetValue(v)")
-    }
     E = p.Append(pnode)
     if E != nil {
-        return elist.Push(E, "List_base::AppendValue: p.Append(pnode)")
+        return p.putBack(pnode, pushError(E, "List_base::AppendValue: p.Append(pnode)"))
     }
     return nil
 }   // End of function List_base::AppendValue.
@@ -333,20 +575,53 @@ func (p *List_base) Prepend(pnode *List_node) error {
     //  List_base::Prepend  //
     //----------------------//
     if p == nil {
-        return elist.New("List_base::Prepend: p == nil")
+        return newError(ErrNilReceiver, "List_base::Prepend: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Prepend", time.Now())
     }
     if pnode == nil {
         return nil
     }
     // Can't put an object in multiple lists.
     if pnode.base != nil {
-        return elist.New("List_base::Prepend: pnode.base != nil")
+        return p.fail(ErrNodeInOtherList, pnode, "List_base::Prepend: pnode.base != nil")
+    }
+    if p.elem_type != nil || p.validator != nil {
+        E := p.checkValue("List_base::Prepend", pnode, pnode.value)
+        if E != nil {
+            return E
+        }
+    }
+    indexed := p.predsValid()
+    counted := p.lenValid()
+    E := p.modify("List_base::Prepend")
+    if E != nil {
+        return E
     }
     pnode.base = p // Register the node with this list-base.
+    p.joining(pnode)
+    if indexed {
+        p.preds[pnode] = nil
+        if p.first != nil {
+            p.preds[p.first] = pnode
+        }
+        p.pred_mod = p.modcount
+    }
     pnode.next = p.first
     p.first = pnode
     if p.last == nil {
         p.last = pnode
+        if p.ready != nil {
+            p.filled()
+        }
+    }
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.prepends, 1)
+    }
+    p.joined(pnode, Change_prepend, nil)
+    if p.max_len > 0 {
+        return p.trim("List_base::Prepend", counted, false)
     }
     return nil
 }   // End of function List_base::Prepend.
@@ -361,18 +636,14 @@ func (p *List_base) PrependValue(v interface{}) error {
     //  List_base::PrependValue //
     //--------------------------//
     if p == nil {
-        return elist.New("List_base::PrependValue: p == nil")
+        return newError(ErrNilReceiver, "List_base::PrependValue: p == nil")
     }
-    var pnode *List_node = new(List_node)
+    var pnode *List_node = p.newNode(v)
     var E error
 
-    E = pnode.SetValue(v)
-    if E != nil {
-        return elist.Push(E, "List_base::PrependValue: pnode.SetValue(v)")
-    }
     E = p.Prepend(pnode)
     if E != nil {
-        return elist.Push(E, "List_base::PrependValue: p.Prepend(pnode)")
+        return p.putBack(pnode, pushError(E, "List_base::PrependValue: p.Prepend(pnode)"))
     }
     return nil
 }   // End of function List_base::PrependValue.
@@ -387,21 +658,46 @@ func (p *List_base) Popfirst() (*List_node, error) {
     //  List_base::Popfirst //
     //----------------------//
     if p == nil {
-        return nil, elist.New("List_base::Popfirst: p == nil")
+        return nil, newError(ErrNilReceiver, "List_base::Popfirst: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Popfirst", time.Now())
     }
     if p.first == nil {
         return nil, nil
     }
     // If "first" is nil and "last" is not, this is a very serious error!
     if p.last == nil {
-        return nil, elist.New("List_base::Popfirst: p.first != p.last == nil")
+        E := p.repairLast("List_base::Popfirst: p.first != p.last == nil")
+        if E != nil {
+            return nil, E
+        }
+    }
+    indexed := p.predsValid()
+    E := p.modify("List_base::Popfirst")
+    if E != nil {
+        return nil, E
     }
     if p.last == p.first {
         p.last = nil
     }
     pnode := p.first
     p.first = pnode.next
+    if indexed {
+        delete(p.preds, pnode)
+        if p.first != nil {
+            p.preds[p.first] = nil
+        }
+        p.pred_mod = p.modcount
+    }
     pnode.unlink()
+    if p.meta_on {
+        p.popped(pnode)
+    }
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.pops, 1)
+    }
+    p.left(pnode, false)
     return pnode, nil
 }   // End of function List_base::Popfirst.
 
@@ -415,7 +711,10 @@ func (p *List_base) Poplast() (*List_node, error) {
     //  List_base::Poplast  //
     //----------------------//
     if p == nil {
-        return nil, elist.New("List_base::Poplast: p == nil")
+        return nil, newError(ErrNilReceiver, "List_base::Poplast: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Poplast", time.Now())
     }
     if p.first == nil {
         return nil, nil
@@ -423,7 +722,15 @@ func (p *List_base) Poplast() (*List_node, error) {
     // List integrity check.
     // If "first" is nil and "last" is not, the list is corrupted.
     if p.last == nil {
-        return nil, elist.New("List_base::Poplast: p.first != p.last == nil")
+        E := p.repairLast("List_base::Poplast: p.first != p.last == nil")
+        if E != nil {
+            return nil, E
+        }
+    }
+    indexed := p.predsValid()
+    E := p.modify("List_base::Poplast")
+    if E != nil {
+        return nil, E
     }
     var pnode *List_node = nil
     // Special case of only one item found in the list.
@@ -432,26 +739,149 @@ func (p *List_base) Poplast() (*List_node, error) {
         p.first = nil
         p.last = nil
         pnode.unlink()
+        if p.meta_on {
+            p.popped(pnode)
+        }
+        if p.stats != nil {
+            atomic.AddUint64(&p.stats.pops, 1)
+        }
+        p.left(pnode, false)
         return pnode, nil
     }
     // Find the second-to-last item in the list.
     var q *List_node
-    for q = p.first; q != nil; q = q.next {
-        if q.next == p.last {
-            break
+    if indexed {
+        q = p.preds[p.last]
+        delete(p.preds, p.last)
+        p.pred_mod = p.modcount
+    } else {
+        var n int = 0
+        for q = p.first; q != nil; q = q.next {
+            n += 1
+            if q.next == p.last {
+                break
+            }
+        }
+        if p.stats != nil {
+            atomic.AddUint64(&p.stats.steps, uint64(n))
         }
     }
     // This should never happen. Indicates list is corrupted.
+    // The last node is not reachable from the first node.
     if q == nil {
-        return nil, elist.New("List_base::Poplast: q == nil")
+        E := p.repairLast("List_base::Poplast: q == nil")
+        if E != nil {
+            return nil, E
+        }
+        return p.Poplast()
     }
     pnode = p.last
     q.next = nil
     p.last = q
     pnode.unlink()
+    if p.meta_on {
+        p.popped(pnode)
+    }
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.pops, 1)
+    }
+    p.left(pnode, false)
     return pnode, nil
 }   // End of function List_base::Poplast.
 
+/*
+List_base::PopfirstValue() pops the first node from the list and returns its
+value. The boolean return value is false if the list was empty, which
+distinguishes an empty list from a node whose value is nil.
+*/
+func (p *List_base) PopfirstValue() (interface{}, bool, error) {
+    //------------------------------//
+    //   List_base::PopfirstValue   //
+    //------------------------------//
+    pnode, E := p.Popfirst()
+    if E != nil {
+        return nil, false, pushError(E, "List_base::PopfirstValue: p.Popfirst()")
+    }
+    if pnode == nil {
+        return nil, false, nil
+    }
+    return pnode.value, true, nil
+}   // End of function List_base::PopfirstValue.
+
+/*
+List_base::PoplastValue() pops the last node from the list and returns its
+value. The boolean return value is false if the list was empty, which
+distinguishes an empty list from a node whose value is nil.
+*/
+func (p *List_base) PoplastValue() (interface{}, bool, error) {
+    //------------------------------//
+    //   List_base::PoplastValue    //
+    //------------------------------//
+    pnode, E := p.Poplast()
+    if E != nil {
+        return nil, false, pushError(E, "List_base::PoplastValue: p.Poplast()")
+    }
+    if pnode == nil {
+        return nil, false, nil
+    }
+    return pnode.value, true, nil
+}   // End of function List_base::PoplastValue.
+
+/*
+List_base::FirstValue() returns the value of the first node of the list without
+removing it. The boolean return value is false if the list is empty.
+*/
+func (p *List_base) FirstValue() (interface{}, bool) {
+    //--------------------------//
+    //  List_base::FirstValue   //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("List_base::FirstValue")
+    }
+    if p == nil || p.first == nil {
+        return nil, false
+    }
+    return p.first.value, true
+}   // End of function List_base::FirstValue.
+
+/*
+List_base::LastValue() returns the value of the last node of the list without
+removing it. The boolean return value is false if the list is empty.
+*/
+func (p *List_base) LastValue() (interface{}, bool) {
+    //--------------------------//
+    //   List_base::LastValue   //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("List_base::LastValue")
+    }
+    if p == nil || p.first == nil || p.last == nil {
+        return nil, false
+    }
+    return p.last.value, true
+}   // End of function List_base::LastValue.
+
+/*
+List_base::CountNilValues() returns the number of nodes in the list whose value
+is nil. Nil values are legal list payloads.
+*/
+func (p *List_base) CountNilValues() int {
+    //------------------------------//
+    //  List_base::CountNilValues   //
+    //------------------------------//
+    if p == nil {
+        nilReceiver("List_base::CountNilValues")
+        return 0
+    }
+    var n int = 0
+    for q := p.first; q != nil; q = q.next {
+        if q.value == nil {
+            n += 1
+        }
+    }
+    return n
+}   // End of function List_base::CountNilValues.
+
 /*
 List_base::Found(*List_node) returns true if and only if the node is currently
 contained in the list.
@@ -462,7 +892,10 @@ func (p *List_base) Found(q *List_node) (bool, error) {
     //   List_base::Found   //
     //----------------------//
     if p == nil {
-        return false, elist.New("List_base::Found: p == nil")
+        return false, newError(ErrNilReceiver, "List_base::Found: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Found", time.Now())
     }
     // Can't find a nil object in any list.
     if q == nil {
@@ -475,11 +908,20 @@ func (p *List_base) Found(q *List_node) (bool, error) {
     // List integrity check.
     // If "first" is nil and "last" is not, this is a very serious error!
     if p.last == nil {
-        return false, elist.New("List_base::Found: p.first != p.last == nil")
+        E := p.repairLast("List_base::Found: p.first != p.last == nil")
+        if E != nil {
+            return false, E
+        }
+    }
+    if p.check == Check_full {
+        E := p.fullCheck("List_base::Found")
+        if E != nil {
+            return false, E
+        }
     }
     // The given object does not belong to this list. So don't even try.
-    if q.base != p {
-        return false, elist.New("List_base::Found: q.base != p")
+    if p.check != Check_off && q.base != p {
+        return false, p.corrupt(ErrNotMember, q, "List_base::Found: q.base != p")
     }
     // Try to find q in the list.
     for pnode := p.first; pnode != nil; pnode = pnode.next {
@@ -494,13 +936,21 @@ func (p *List_base) Found(q *List_node) (bool, error) {
 List_base::Remove() removes the given node from the list, if it is a valid
 member of the list, and returns the removed node to the caller.
 The returned node is always either nil or the same as the requested node.
+The first removal of a node other than the first node builds an index of the
+predecessors of the nodes in O(n) time. Further removals cost O(1) while the
+list is only modified by List_base::Remove(), List_base::Append(),
+List_base::Prepend(), List_base::Popfirst() and List_base::Poplast(). Other
+modifications invalidate the index, and the next removal rebuilds it.
 */
 func (p *List_base) Remove(q *List_node) (*List_node, error) {
     //----------------------//
     //   List_base::Remove  //
     //----------------------//
     if p == nil {
-        return nil, elist.New("List_base::Remove: p == nil")
+        return nil, newError(ErrNilReceiver, "List_base::Remove: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Remove", time.Now())
     }
     // Can't find a nil object in any list.
     if q == nil {
@@ -513,11 +963,25 @@ func (p *List_base) Remove(q *List_node) (*List_node, error) {
     // List integrity check.
     // If "first" is nil and "last" is not, this is a very serious error!
     if p.last == nil {
-        return nil, elist.New("List_base::Remove: p.first != p.last == nil")
+        E := p.repairLast("List_base::Remove: p.first != p.last == nil")
+        if E != nil {
+            return nil, E
+        }
+    }
+    if p.check == Check_full {
+        E := p.fullCheck("List_base::Remove")
+        if E != nil {
+            return nil, E
+        }
     }
     // The given object does not belong to the list.
-    if q.base != p {
-        return nil, elist.New("List_base::Remove: q.base != p")
+    if p.check != Check_off && q.base != p {
+        return nil, p.corrupt(ErrNotMember, q, "List_base::Remove: q.base != p")
+    }
+    indexed := p.predsValid()
+    E := p.modify("List_base::Remove")
+    if E != nil {
+        return nil, E
     }
     // Special case of popping the first element.
     if p.first == q {
@@ -525,28 +989,48 @@ func (p *List_base) Remove(q *List_node) (*List_node, error) {
             p.last = nil
         }
         p.first = q.next
+        if indexed {
+            delete(p.preds, q)
+            if p.first != nil {
+                p.preds[p.first] = nil
+            }
+            p.pred_mod = p.modcount
+        }
 
         // Unlink the node from the list base.
         q.unlink()
+        if p.stats != nil {
+            atomic.AddUint64(&p.stats.removals, 1)
+        }
+        p.left(q, false)
         return q, nil
     }
-    // Try to find the predecessor of q in the list.
-    var pnode *List_node
-    for pnode = p.first; pnode != nil; pnode = pnode.next {
-        if pnode.next == q {
-            break
-        }
+    // Look up the predecessor of q in the predecessor index. The index is
+    // built by the first search, and kept up to date by later removals,
+    // appends, prepends and pops, so that a series of removals costs O(1)
+    // each. Any other modification invalidates it.
+    if !indexed {
+        p.indexPreds()
     }
+    pnode := p.preds[q]
     // Didn't find the object in the list. Should never happen!
     if pnode == nil {
-        return nil, elist.New("List_base::Remove: pnode == nil")
+        return nil, p.fail(ErrCorruptList, q, "List_base::Remove: pnode == nil")
     }
     pnode.next = q.next
     if p.last == q {
         p.last = pnode
+    } else {
+        p.preds[q.next] = pnode
     }
+    delete(p.preds, q)
+    p.pred_mod = p.modcount
     // Unlink the node from the list.
     q.unlink()
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.removals, 1)
+    }
+    p.left(q, false)
     return q, nil
 }   // End of function List_base::Remove.
 
@@ -563,15 +1047,26 @@ func (p *List_base) Clear() error {
     //   List_base::Clear   //
     //----------------------//
     if p == nil {
-        return elist.New("List_base::Clear: p == nil")
+        return newError(ErrNilReceiver, "List_base::Clear: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Clear", time.Now())
     }
     if p.first == nil {
         return nil
     }
     // If "first" is nil and "last" is not, this is a very serious error!
     if p.last == nil {
-        return elist.New("List_base::Clear: p.first != p.last == nil")
+        E := p.repairLast("List_base::Clear: p.first != p.last == nil")
+        if E != nil {
+            return E
+        }
+    }
+    E := p.modify("List_base::Clear")
+    if E != nil {
+        return E
     }
+    p.preds = nil
     // Pop and unlink the first element recursively until nothing is left.
     for p.first != nil {
         if p.last == p.first {
@@ -580,10 +1075,294 @@ func (p *List_base) Clear() error {
         pnode := p.first
         p.first = pnode.next
         pnode.unlink()
+        if p.release != nil {
+            p.retire(pnode, true)
+        }
+    }
+    if p.watchers != nil {
+        p.notify(Change_clear, nil, nil)
+    }
+    if p.hooks != nil {
+        p.hooks.cleared()
     }
     return nil
 }   // End of function List_base::Clear.
 
+/*
+List_base::ModCount() returns the modification count of the list. This is
+incremented by every insertion and removal of nodes, but not by changes to node
+values. Two equal counts indicate that the structure of the list is unchanged.
+*/
+func (p *List_base) ModCount() uint64 {
+    //----------------------//
+    //  List_base::ModCount //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::ModCount")
+        return 0
+    }
+    return p.modcount
+}   // End of function List_base::ModCount.
+
+/*
+List_base::modify() is a private member function for internal use in this
+package.
+It must be called by every method immediately before it modifies the structure
+of the list. It performs the List_base::writable() checks and then increments
+the modification count.
+*/
+func (p *List_base) modify(fn string) error {
+    //----------------------//
+    //   List_base::modify  //
+    //----------------------//
+    E := p.writable(fn)
+    if E != nil {
+        return E
+    }
+    if p.debugging() {
+        E = p.debugCheck(fn)
+        if E != nil {
+            return E
+        }
+    }
+    if p.release != nil {
+        p.release.sweep(time.Now(), false)
+    }
+    p.modcount += 1
+    return nil
+}   // End of function List_base::modify.
+
+/*
+List_base::insertAfter() is a private member function for internal use in this
+package.
+It links the node q into the list after the member node prev, or at the front
+of the list if prev is nil. The caller must have called List_base::modify().
+*/
+func (p *List_base) insertAfter(prev, q *List_node) {
+    //--------------------------//
+    //  List_base::insertAfter  //
+    //--------------------------//
+    q.base = p
+    if prev == nil {
+        q.next = p.first
+        p.first = q
+    } else {
+        q.next = prev.next
+        prev.next = q
+    }
+    if p.last == prev {
+        p.last = q
+    }
+    if p.ready != nil && p.last == q && p.first == q {
+        p.filled()
+    }
+}   // End of function List_base::insertAfter.
+
+/*
+List_base::removeAfter() is a private member function for internal use in this
+package.
+It unlinks the member node q from the list, given its predecessor prev, which is
+nil if q is the first node. The caller must have called List_base::modify().
+*/
+func (p *List_base) removeAfter(prev, q *List_node) {
+    //--------------------------//
+    //  List_base::removeAfter  //
+    //--------------------------//
+    if prev == nil {
+        p.first = q.next
+    } else {
+        prev.next = q.next
+    }
+    if p.last == q {
+        p.last = prev
+    }
+    q.unlink()
+}   // End of function List_base::removeAfter.
+
+/*
+List_base::joining() is a private member function for internal use in this
+package.
+It prepares the node q, which is being inserted into the list, by stamping its
+metadata, cancelling a waiting release of its value, and interning its value.
+The caller must have called List_base::modify() and checked the value with
+List_base::checkValue().
+*/
+func (p *List_base) joining(q *List_node) {
+    //--------------------------//
+    //   List_base::joining     //
+    //--------------------------//
+    if p.meta_on {
+        p.stamp(q)
+    }
+    if p.release != nil {
+        p.revive(q)
+    }
+    if p.interner != nil {
+        q.value = p.interner.Intern(q.value)
+    }
+}   // End of function List_base::joining.
+
+/*
+List_base::joined() is a private member function for internal use in this
+package.
+It reports the node q, which has been linked into the list, to the watchers
+with the change op, and to the OnInsert hook. For Change_insert, after is the
+predecessor of q, or nil if q is the first node.
+*/
+func (p *List_base) joined(q *List_node, op Change_op, after *List_node) {
+    //--------------------------//
+    //    List_base::joined     //
+    //--------------------------//
+    if p.watchers != nil {
+        p.notify(op, q, after)
+    }
+    if p.hooks != nil {
+        p.hooks.inserted(q)
+    }
+}   // End of function List_base::joined.
+
+/*
+List_base::left() is a private member function for internal use in this
+package.
+It is called after the node q has been unlinked from the list. The value of q
+waits for its release, or it is released at once if now is true, and the
+removal is reported to the watchers and to the OnRemove hook.
+*/
+func (p *List_base) left(q *List_node, now bool) {
+    //--------------------------//
+    //     List_base::left      //
+    //--------------------------//
+    if p.release != nil {
+        p.retire(q, now)
+    }
+    p.reportRemove(q)
+}   // End of function List_base::left.
+
+/*
+List_base::reportRemove() is a private member function for internal use in this
+package.
+It reports the removal of the node q to the watchers and to the OnRemove hook,
+like List_base::left(), but without releasing its value.
+*/
+func (p *List_base) reportRemove(q *List_node) {
+    //------------------------------//
+    //   List_base::reportRemove    //
+    //------------------------------//
+    if p.watchers != nil {
+        p.notify(Change_remove, q, nil)
+    }
+    if p.hooks != nil {
+        p.hooks.removed(q)
+    }
+}   // End of function List_base::reportRemove.
+
+/*
+List_base::predsValid() is a private member function for internal use in this
+package.
+It returns true if the predecessor index of the list is up to date. It must be
+called before List_base::modify(), because every modification which does not
+update the index invalidates it.
+*/
+func (p *List_base) predsValid() bool {
+    //--------------------------//
+    //  List_base::predsValid   //
+    //--------------------------//
+    return p.preds != nil && p.pred_mod == p.modcount
+}   // End of function List_base::predsValid.
+
+/*
+List_base::indexPreds() is a private member function for internal use in this
+package.
+It builds the predecessor index of the list, which maps each node to its
+predecessor, or to nil for the first node. This costs O(n) time and memory.
+The caller must have called List_base::modify().
+*/
+func (p *List_base) indexPreds() {
+    //--------------------------//
+    //  List_base::indexPreds   //
+    //--------------------------//
+    if p.preds == nil {
+        p.preds = make(map[*List_node]*List_node)
+    } else {
+        clear(p.preds)
+    }
+    var prev *List_node
+    var n int = 0
+    for q := p.first; q != nil; q = q.next {
+        p.preds[q] = prev
+        n += 1
+        // Stop at the last node, even if the chain is corrupt.
+        if q == p.last {
+            break
+        }
+        prev = q
+    }
+    p.pred_mod = p.modcount
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.steps, uint64(n))
+    }
+}   // End of function List_base::indexPreds.
+
+/*
+List_base::All() returns a sequence which yields the nodes of the list from the
+first to the last, for use in a range-over-func loop:
+    for q, E := range b.All() { ... }
+The nodes are delivered by List_iter::Next(), with its integrity checks. If an
+error occurs, a nil node and the error are yielded, and the sequence ends.
+*/
+func (p *List_base) All() iter.Seq2[*List_node, error] {
+    //----------------------//
+    //    List_base::All    //
+    //----------------------//
+    return func(yield func(*List_node, error) bool) {
+        var it List_iter
+        E := it.Init(p)
+        for E == nil {
+            var q *List_node
+            q, E = it.Next()
+            if E != nil || q == nil {
+                break
+            }
+            if !yield(q, nil) {
+                return
+            }
+        }
+        if E != nil {
+            yield(nil, pushError(E, "List_base::All: it.Next()"))
+        }
+    }
+}   // End of function List_base::All.
+
+/*
+List_base::ReverseAll() returns a sequence which yields the nodes of the list
+from the last to the first, for use in a range-over-func loop:
+    for q, E := range b.ReverseAll() { ... }
+The nodes are collected with the integrity checks of List_iter::Next() before
+the first node is yielded, which costs O(n) time and space. If an error occurs,
+a nil node and the error are yielded, and the sequence ends.
+*/
+func (p *List_base) ReverseAll() iter.Seq2[*List_node, error] {
+    //--------------------------//
+    //  List_base::ReverseAll   //
+    //--------------------------//
+    return func(yield func(*List_node, error) bool) {
+        var it List_iter
+        E := it.Init(p)
+        if E == nil {
+            E = it.loadChain()
+        }
+        if E != nil {
+            yield(nil, pushError(E, "List_base::ReverseAll: it.loadChain()"))
+            return
+        }
+        for i := len(it.chain) - 1; i >= 0; i -= 1 {
+            if !yield(it.chain[i], nil) {
+                return
+            }
+        }
+    }
+}   // End of function List_base::ReverseAll.
+
 //=============================================================================
 //=============================================================================
 
@@ -603,8 +1382,24 @@ type List_iter struct {
     //----------------------//
     base    *List_base // The list which is used for the iteration.
     current *List_node // The last node delivered by the iterator.
+    prev    *List_node // The predecessor of the current node.
+    pos     int        // The number of nodes delivered so far.
+    stale   bool       // The current node was removed or inserted.
+
+    modcount uint64 // The list's modification count at the last Next-call.
+
+    // Copy of the chain for backward traversal. See List_iter::Prev().
+    chain    []*List_node
+    chainmod uint64 // The list's modification count when chain was made.
 }
 
+/*
+ErrConcurrentModification is returned by List_iter::Next() when the structure of
+the list has been modified since the previous Next-call, other than through the
+iterator itself.
+*/
+var ErrConcurrentModification = errors.New("s2list: list modified during iteration")
+
 /*
 List_iter::Init() initializes a list-iterator to point at a given list-base.
 */
@@ -613,10 +1408,13 @@ func (p *List_iter) Init(b *List_base) error {
     //    List_iter::Init   //
     //----------------------//
     if p == nil {
-        return elist.New("List_base::Init: p == nil")
+        return newError(ErrNilReceiver, "List_base::Init: p == nil")
     }
     p.base = b
     p.current = nil
+    p.prev = nil
+    p.pos = 0
+    p.stale = false
     return nil
 }   // End of function List_iter::Init.
 
@@ -628,9 +1426,12 @@ func (p *List_iter) Restart() error {
     //  List_iter::Restart  //
     //----------------------//
     if p == nil {
-        return elist.New("List_base::Restart: p == nil")
+        return newError(ErrNilReceiver, "List_base::Restart: p == nil")
     }
     p.current = nil
+    p.prev = nil
+    p.pos = 0
+    p.stale = false
     return nil
 }   // End of function List_iter::Restart.
 
@@ -642,6 +1443,7 @@ func (p *List_iter) ItemCount() int {
     // List_iter::ItemCount //
     //----------------------//
     if p == nil {
+        nilReceiver("List_iter::ItemCount")
         return 0
     }
     if p.base == nil {
@@ -659,6 +1461,7 @@ func (p *List_iter) ItemCountValid() (int, int, int) {
     //   List_iter::ItemCountValid  //
     //------------------------------//
     if p == nil {
+        nilReceiver("List_iter::ItemCountValid")
         return 0, 0, 0
     }
     if p.base == nil {
@@ -675,7 +1478,10 @@ removed from the list, and possibly appended to a different list,
 List_iter::Next() will return a nil node-pointer and a non-nil error.
 
 NOTE: The list should not be modified while iteration is occurring.
-Results could be perplexing if the list is modified between Next-calls.
+If the structure of the list is modified between Next-calls, List_iter::Next()
+returns ErrConcurrentModification. Call List_iter::Restart() to start again.
+To iterate a stable view of a list which is being modified, iterate a snapshot.
+See List_base::Snapshot().
 */
 func (p *List_iter) Next() (*List_node, error) {
     //----------------------//
@@ -686,45 +1492,396 @@ func (p *List_iter) Next() (*List_node, error) {
       If all goes well, this is incremented and returned to the caller.
       ------------------------------------------------------------------------------*/
     if p == nil {
-        return nil, elist.New("List_base::Next: p == nil")
+        return nil, newError(ErrNilReceiver, "List_base::Next: p == nil")
     }
     // If there's not list-base, there's nothing to do.
     if p.base == nil {
-        return nil, elist.New("List_base::Next: p.base == nil")
+        return nil, newError(ErrNilReceiver, "List_base::Next: p.base == nil")
     }
     if p.current == nil {
         p.current = p.base.first
+        p.prev = nil
+        p.modcount = p.base.modcount
+        if p.base.corruption != Corruption_fail {
+            E := p.recover()
+            if E != nil {
+                return nil, E
+            }
+        }
         // Empty list.
         if p.current == nil {
             return nil, nil
         }
+        // The whole list is verified once at the start of the iteration.
+        if p.base.check == Check_full {
+            E := p.base.fullCheck("List_base::Next")
+            if E != nil {
+                p.current = nil
+                return nil, E
+            }
+        }
         // Corruption. The first node is not registered in a list!
         // Leave the current-pointer where it is to avoid infinite loops.
-        if p.current.base == nil {
-            return nil, elist.New("List_base::Next: p.current.base == nil")
+        if p.base.check != Check_off && p.current.base == nil {
+            return nil, p.base.fail(ErrCorruptList, p.current, "List_base::Next: p.current.base == nil")
         }
         // Corruption. The first node is in the wrong list!
         // Leave the current-pointer where it is to avoid infinite loops.
-        if p.current.base != p.base {
-            return nil, elist.New("List_base::Next: p.current.base != p.base")
+        if p.base.check != Check_off && p.current.base != p.base {
+            return nil, p.base.corrupt(ErrCorruptList, p.current, "List_base::Next: p.current.base != p.base")
         }
     } else {
+        // Fail fast if nodes have been inserted or removed since the last
+        // Next-call. Leave the current-pointer where it is.
+        if p.base.modcount != p.modcount {
+            return nil, ErrConcurrentModification
+        }
         // The current node is not registered in a list!
         // Leave the current-pointer where it is to avoid infinite loops.
-        if p.current.base == nil {
-            return nil, elist.New("List_base::Next: p.current.base == nil")
+        if p.base.check != Check_off && p.current.base == nil {
+            return nil, p.base.fail(ErrCorruptList, p.current, "List_base::Next: p.current.base == nil")
         }
         // The current node is in the wrong list!
         // Leave the current-pointer where it is to avoid infinite loops.
-        if p.current.base != p.base {
-            return nil, elist.New("List_base::Next: p.current.base != p.base")
+        if p.base.check != Check_off && p.current.base != p.base {
+            return nil, p.base.corrupt(ErrCorruptList, p.current, "List_base::Next: p.current.base != p.base")
         }
         // End of the list.
         // Leave the current-pointer where it is to avoid infinite loops.
         if p.current.next == nil {
             return nil, nil
         }
+        p.prev = p.current
         p.current = p.current.next
+        if p.base.corruption != Corruption_fail {
+            E := p.recover()
+            if E != nil {
+                return nil, E
+            }
+            // The bad nodes were at the end of the list.
+            if p.current == nil {
+                p.current = p.prev
+                return nil, nil
+            }
+        }
+    }
+    p.pos += 1
+    p.stale = false
+    if p.base.stats != nil {
+        atomic.AddUint64(&p.base.stats.steps, 1)
     }
     return p.current, nil
 }   // End of function List_iter::Next.
+
+/*
+List_iter::RemoveCurrent() removes the node which was delivered by the last
+Next-call from the list and returns it to the caller. The iteration remains
+valid. The following Next-call delivers the node which followed the removed
+node. This costs O(1) because the iterator remembers the predecessor of the
+current node.
+An error is returned if there is no current node, which is the case before the
+first Next-call, at the end of an empty list, and after a RemoveCurrent-call or
+InsertAfterCurrent-call which has not been followed by a Next-call.
+*/
+func (p *List_iter) RemoveCurrent() (*List_node, error) {
+    //------------------------------//
+    //   List_iter::RemoveCurrent   //
+    //------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_iter::RemoveCurrent: p == nil")
+    }
+    if p.base == nil {
+        return nil, newError(ErrNilReceiver, "List_iter::RemoveCurrent: p.base == nil")
+    }
+    if p.current == nil || p.stale {
+        return nil, p.base.fail(ErrInvalidArgument, nil, "List_iter::RemoveCurrent: no current node")
+    }
+    if p.base.modcount != p.modcount {
+        return nil, ErrConcurrentModification
+    }
+    if p.current.base != p.base {
+        return nil, p.base.corrupt(ErrCorruptList, p.current, "List_iter::RemoveCurrent: p.current.base != p.base")
+    }
+    // Nodes with wrong base-pointers may have been skipped.
+    if p.prev != nil && p.prev.next != p.current {
+        return nil, p.base.fail(ErrCorruptList, p.current, "List_iter::RemoveCurrent: p.prev.next != p.current")
+    }
+    E := p.base.modify("List_iter::RemoveCurrent")
+    if E != nil {
+        return nil, E
+    }
+    q := p.current
+    p.base.removeAfter(p.prev, q)
+    if p.base.stats != nil {
+        atomic.AddUint64(&p.base.stats.removals, 1)
+    }
+    p.base.left(q, false)
+    p.modcount = p.base.modcount
+
+    // The predecessor becomes the current node. Its own predecessor is not
+    // known, but it is not needed until the next Next-call.
+    p.current = p.prev
+    p.prev = nil
+    p.pos -= 1
+    p.stale = true
+    return q, nil
+}   // End of function List_iter::RemoveCurrent.
+
+/*
+List_iter::InsertAfterCurrent() copies the given value to a newly created node
+and inserts it into the list after the node which was delivered by the last
+Next-call. Before the first Next-call, the node is inserted at the front of the
+list. The iteration remains valid, and the new node is not delivered by the
+following Next-calls. So repeated insertions keep their order. If the list has
+a length limit and is full, ErrInvalidArgument is returned, because an eviction
+could remove the node of the iteration. See List_base::SetMaxLen().
+*/
+func (p *List_iter) InsertAfterCurrent(v interface{}) error {
+    //----------------------------------//
+    //   List_iter::InsertAfterCurrent  //
+    //----------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_iter::InsertAfterCurrent: p == nil")
+    }
+    if p.base == nil {
+        return newError(ErrNilReceiver, "List_iter::InsertAfterCurrent: p.base == nil")
+    }
+    if p.current != nil {
+        if p.base.modcount != p.modcount {
+            return ErrConcurrentModification
+        }
+        if p.current.base != p.base {
+            return p.base.corrupt(ErrCorruptList, p.current, "List_iter::InsertAfterCurrent: p.current.base != p.base")
+        }
+    }
+    if p.base.elem_type != nil || p.base.validator != nil {
+        E := p.base.checkValue("List_iter::InsertAfterCurrent", nil, v)
+        if E != nil {
+            return E
+        }
+    }
+    if p.base.max_len > 0 && p.base.full() {
+        return p.base.fail(ErrInvalidArgument, nil, "List_iter::InsertAfterCurrent: the list is full")
+    }
+    E := p.base.modify("List_iter::InsertAfterCurrent")
+    if E != nil {
+        return E
+    }
+    pnode := p.base.newNode(v)
+    p.base.joining(pnode)
+    p.base.insertAfter(p.current, pnode)
+    p.base.joined(pnode, Change_insert, p.current)
+    p.modcount = p.base.modcount
+
+    // Step over the new node.
+    p.prev = p.current
+    p.current = pnode
+    p.pos += 1
+    p.stale = true
+    return nil
+}   // End of function List_iter::InsertAfterCurrent.
+
+/*
+List_iter::Prev() moves the iterator one node backwards and returns the new
+current node. If no Next-call or Prev-call has been made since the iterator was
+initialized or restarted, Prev() starts at the last node of the list. At the
+front of the list, the return value is nil and the current node is unchanged,
+so that a following Next-call delivers the second node.
+Next-calls and Prev-calls may be mixed freely.
+
+Since the list is singly linked, the first Prev-call copies the chain of nodes
+into the iterator, which costs O(n). Subsequent Prev-calls cost O(1) until the
+list is modified.
+*/
+func (p *List_iter) Prev() (*List_node, error) {
+    //----------------------//
+    //    List_iter::Prev   //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_iter::Prev: p == nil")
+    }
+    if p.base == nil {
+        return nil, newError(ErrNilReceiver, "List_iter::Prev: p.base == nil")
+    }
+    if p.current != nil && p.base.modcount != p.modcount {
+        return nil, ErrConcurrentModification
+    }
+    // Start of the list, after the first node was removed.
+    if p.current == nil && p.stale {
+        return nil, nil
+    }
+    if p.chain == nil || p.chainmod != p.base.modcount ||
+        (len(p.chain) > 0 && p.chain[0] != p.base.first) {
+        E := p.loadChain()
+        if E != nil {
+            return nil, pushError(E, "List_iter::Prev: p.loadChain()")
+        }
+    }
+    var i int
+    if p.current == nil {
+        // Start at the end of the list.
+        i = len(p.chain) - 1
+        if i < 0 {
+            return nil, nil
+        }
+        p.modcount = p.base.modcount
+    } else {
+        // Front of the list.
+        // Leave the current-pointer where it is.
+        i = p.pos - 2
+        if i < 0 {
+            return nil, nil
+        }
+    }
+    p.current = p.chain[i]
+    p.prev = nil
+    if i > 0 {
+        p.prev = p.chain[i-1]
+    }
+    p.pos = i + 1
+    p.stale = false
+    return p.current, nil
+}   // End of function List_iter::Prev.
+
+/*
+List_iter::loadChain() is a private member function for internal use in this
+package.
+It copies the addresses of all nodes of the list into the chain-field of the
+iterator, checking their base-pointers.
+*/
+func (p *List_iter) loadChain() error {
+    //--------------------------//
+    //  List_iter::loadChain    //
+    //--------------------------//
+    if p.base == nil {
+        return newError(ErrNilReceiver, "List_iter::loadChain: p.base == nil")
+    }
+    chain := make([]*List_node, 0)
+    for q := p.base.first; q != nil; q = q.next {
+        if q.base != p.base {
+            return p.base.fail(ErrCorruptList, q, "List_iter::loadChain: q.base != p.base")
+        }
+        chain = append(chain, q)
+    }
+    p.chain = chain
+    p.chainmod = p.base.modcount
+    return nil
+}   // End of function List_iter::loadChain.
+
+/*
+List_iter::Peek() returns the node which the next Next-call would return, with
+the same integrity checks, but without advancing the iterator.
+*/
+func (p *List_iter) Peek() (*List_node, error) {
+    //----------------------//
+    //    List_iter::Peek   //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_iter::Peek: p == nil")
+    }
+    // Advance a copy of the iterator. The original is unaffected.
+    it := *p
+    q, E := it.Next()
+    if E == ErrConcurrentModification {
+        return nil, E
+    }
+    if E != nil {
+        return nil, pushError(E, "List_iter::Peek: it.Next()")
+    }
+    return q, nil
+}   // End of function List_iter::Peek.
+
+/*
+List_iter::Skip() advances the iterator by up to n nodes, as if Next() had been
+called n times, and returns the number of nodes which were skipped. This is less
+than n if the end of the list is reached or an error occurs.
+*/
+func (p *List_iter) Skip(n int) (int, error) {
+    //----------------------//
+    //    List_iter::Skip   //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_iter::Skip: p == nil")
+    }
+    var i int
+    for i = 0; i < n; i += 1 {
+        q, E := p.Next()
+        if E == ErrConcurrentModification {
+            return i, E
+        }
+        if E != nil {
+            return i, pushError(E, "List_iter::Skip: p.Next()")
+        }
+        if q == nil {
+            break
+        }
+    }
+    return i, nil
+}   // End of function List_iter::Skip.
+
+/*
+List_iter::Seek() positions the iterator at the node q, as if q had been
+delivered by the last Next-call. So the following Next-call delivers the node
+after q. The node must be a member of the iterator's list. Since the position of
+q in the list must be found, this costs O(n).
+*/
+func (p *List_iter) Seek(q *List_node) error {
+    //----------------------//
+    //    List_iter::Seek   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_iter::Seek: p == nil")
+    }
+    if p.base == nil {
+        return newError(ErrNilReceiver, "List_iter::Seek: p.base == nil")
+    }
+    if q == nil {
+        return p.base.fail(ErrNilArgument, nil, "List_iter::Seek: q == nil")
+    }
+    if q.base != p.base {
+        return p.base.fail(ErrNotMember, q, "List_iter::Seek: q.base != p.base")
+    }
+    var prev *List_node = nil
+    var i int = 1
+    for pnode := p.base.first; pnode != nil; pnode = pnode.next {
+        if pnode.base != p.base {
+            return p.base.fail(ErrCorruptList, pnode, "List_iter::Seek: pnode.base != p.base")
+        }
+        if pnode == q {
+            p.current = q
+            p.prev = prev
+            p.pos = i
+            p.stale = false
+            p.modcount = p.base.modcount
+            return nil
+        }
+        prev = pnode
+        i += 1
+    }
+    // The node claims to be in the list, but it isn't. Should never happen!
+    return p.base.fail(ErrNotMember, q, "List_iter::Seek: q not found")
+}   // End of function List_iter::Seek.
+
+/*
+List_iter::NextIndexed() is like List_iter::Next(), but it also returns the
+zero-based position of the delivered node in the list. The position is
+maintained by the iterator, so it stays correct across RemoveCurrent-calls,
+InsertAfterCurrent-calls, Prev-calls and Seek-calls. At the end of the list, or
+if an error occurs, the returned position is -1.
+*/
+func (p *List_iter) NextIndexed() (int, *List_node, error) {
+    //------------------------------//
+    //    List_iter::NextIndexed    //
+    //------------------------------//
+    q, E := p.Next()
+    if E == ErrConcurrentModification {
+        return -1, nil, E
+    }
+    if E != nil {
+        return -1, nil, pushError(E, "List_iter::NextIndexed: p.Next()")
+    }
+    if q == nil {
+        return -1, nil, nil
+    }
+    return p.pos - 1, q, nil
+}   // End of function List_iter::NextIndexed.
@@ -0,0 +1,142 @@
+// src/go/s2list_adapter.go   2026-10-17
+// Lazy filtering and mapping adapters for s2list iterators.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_iter::Where
+List_iter::Select
+List_iter::values
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+Value_iter::
+Value_iter::Next
+Value_iter::Where
+Value_iter::Select
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Value_iter delivers a sequence of values which is derived lazily from a
+List_iter by List_iter::Where() and List_iter::Select(). Adapters may be chained:
+    vi := it.Where(is_ready).Select(get_id)
+Each call to Value_iter::Next() advances the underlying List_iter only as far as
+necessary to produce the next value. No intermediate lists are created.
+    next func() (interface{}, bool, error) // Produces the next value.
+*/
+type Value_iter struct {
+    //----------------------//
+    //     Value_iter::     //
+    //----------------------//
+    next func() (interface{}, bool, error) // Produces the next value.
+}
+
+/*
+List_iter::Where() returns a value iterator which delivers the values of the
+nodes of this iterator for which pred returns true.
+*/
+func (p *List_iter) Where(pred func(interface{}) bool) *Value_iter {
+    //----------------------//
+    //   List_iter::Where   //
+    //----------------------//
+    return p.values().Where(pred)
+}   // End of function List_iter::Where.
+
+/*
+List_iter::Select() returns a value iterator which delivers f(v) for the value v
+of each node of this iterator.
+*/
+func (p *List_iter) Select(f func(interface{}) interface{}) *Value_iter {
+    //----------------------//
+    //   List_iter::Select  //
+    //----------------------//
+    return p.values().Select(f)
+}   // End of function List_iter::Select.
+
+/*
+List_iter::values() is a private member function for internal use in this
+package.
+It returns a value iterator which delivers the values of the nodes of this
+iterator unchanged.
+*/
+func (p *List_iter) values() *Value_iter {
+    //----------------------//
+    //   List_iter::values  //
+    //----------------------//
+    vi := new(Value_iter)
+    vi.next = func() (interface{}, bool, error) {
+        q, E := p.Next()
+        if E == ErrConcurrentModification {
+            return nil, false, E
+        }
+        if E != nil {
+            return nil, false, pushError(E, "Value_iter::Next: p.Next()")
+        }
+        if q == nil {
+            return nil, false, nil
+        }
+        return q.value, true, nil
+    }
+    return vi
+}   // End of function List_iter::values.
+
+/*
+Value_iter::Next() returns the next value. The boolean return value is false at
+the end of the sequence, which distinguishes the end from a nil value.
+*/
+func (p *Value_iter) Next() (interface{}, bool, error) {
+    //----------------------//
+    //   Value_iter::Next   //
+    //----------------------//
+    if p == nil || p.next == nil {
+        return nil, false, newError(ErrNilReceiver, "Value_iter::Next: p == nil")
+    }
+    return p.next()
+}   // End of function Value_iter::Next.
+
+/*
+Value_iter::Where() returns a value iterator which delivers the values of this
+iterator for which pred returns true.
+*/
+func (p *Value_iter) Where(pred func(interface{}) bool) *Value_iter {
+    //----------------------//
+    //   Value_iter::Where  //
+    //----------------------//
+    vi := new(Value_iter)
+    vi.next = func() (interface{}, bool, error) {
+        for {
+            v, ok, E := p.Next()
+            if E != nil || !ok {
+                return nil, false, E
+            }
+            if pred == nil || pred(v) {
+                return v, true, nil
+            }
+        }
+    }
+    return vi
+}   // End of function Value_iter::Where.
+
+/*
+Value_iter::Select() returns a value iterator which delivers f(v) for each value
+v of this iterator.
+*/
+func (p *Value_iter) Select(f func(interface{}) interface{}) *Value_iter {
+    //----------------------//
+    //  Value_iter::Select  //
+    //----------------------//
+    vi := new(Value_iter)
+    vi.next = func() (interface{}, bool, error) {
+        v, ok, E := p.Next()
+        if E != nil || !ok {
+            return nil, false, E
+        }
+        if f != nil {
+            v = f(v)
+        }
+        return v, true, nil
+    }
+    return vi
+}   // End of function Value_iter::Select.
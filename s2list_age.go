@@ -0,0 +1,128 @@
+// src/go/s2list_age.go   2026-10-17
+// Age and latency statistics for s2list lists with metadata.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::AgeStats
+List_base::latency
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "math"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+latency_bounds are the upper bounds of the dequeue latency histogram buckets.
+The last bucket has no upper bound.
+*/
+var latency_bounds = []time.Duration{
+    time.Microsecond,
+    10 * time.Microsecond,
+    100 * time.Microsecond,
+    time.Millisecond,
+    10 * time.Millisecond,
+    100 * time.Millisecond,
+    time.Second,
+    10 * time.Second,
+    100 * time.Second,
+    1000 * time.Second,
+    time.Duration(math.MaxInt64),
+}
+
+/*
+A Latency_bucket is one bucket of a dequeue latency histogram.
+    Upper time.Duration // Latencies up to this bound are counted.
+    Count uint64        // Number of nodes counted in this bucket.
+A latency is counted in the first bucket whose upper bound is not below it.
+The upper bound of the last bucket is the maximum time.Duration.
+*/
+type Latency_bucket struct {
+    Upper time.Duration // Latencies up to this bound are counted.
+    Count uint64        // Number of nodes counted in this bucket.
+}
+
+/*
+An Age_stats describes the age of the nodes which are in a list, and the
+latencies of the nodes which have been popped from it.
+    Count   int              // Number of nodes in the list with metadata.
+    Min     time.Duration    // Age of the youngest node.
+    Avg     time.Duration    // Average age of the nodes.
+    Max     time.Duration    // Age of the oldest node.
+    Latency []Latency_bucket // Histogram of the dequeue latencies.
+The ages are zero if Count is zero. The dequeue latency of a node is the time
+from its insertion until it was popped by List_base::Popfirst() or
+List_base::Poplast() while metadata was enabled.
+*/
+type Age_stats struct {
+    Count   int              // Number of nodes in the list with metadata.
+    Min     time.Duration    // Age of the youngest node.
+    Avg     time.Duration    // Average age of the nodes.
+    Max     time.Duration    // Age of the oldest node.
+    Latency []Latency_bucket // Histogram of the dequeue latencies.
+}
+
+/*
+List_base::AgeStats() returns the age statistics of the nodes in the list,
+based on the insertion times in their metadata. Nodes without metadata are
+ignored. See List_base::EnableMeta(). This costs O(n).
+*/
+func (p *List_base) AgeStats() (*Age_stats, error) {
+    //----------------------//
+    //  List_base::AgeStats //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::AgeStats: p == nil")
+    }
+    st := new(Age_stats)
+    now := time.Now()
+    var sum float64 = 0
+    for q := p.first; q != nil; q = q.next {
+        if q.meta == nil {
+            continue
+        }
+        age := now.Sub(q.meta.Inserted)
+        if st.Count == 0 || age < st.Min {
+            st.Min = age
+        }
+        if st.Count == 0 || age > st.Max {
+            st.Max = age
+        }
+        sum += float64(age)
+        st.Count += 1
+    }
+    if st.Count > 0 {
+        st.Avg = time.Duration(sum / float64(st.Count))
+    }
+    st.Latency = make([]Latency_bucket, len(latency_bounds))
+    for i, upper := range latency_bounds {
+        st.Latency[i].Upper = upper
+        if i < len(p.meta_lat) {
+            st.Latency[i].Count = p.meta_lat[i]
+        }
+    }
+    return st, nil
+}   // End of function List_base::AgeStats.
+
+/*
+List_base::latency() is a private member function for internal use in this
+package.
+It counts a dequeue latency in the histogram.
+*/
+func (p *List_base) latency(d time.Duration) {
+    //----------------------//
+    //  List_base::latency  //
+    //----------------------//
+    if p.meta_lat == nil {
+        p.meta_lat = make([]uint64, len(latency_bounds))
+    }
+    for i, upper := range latency_bounds {
+        if d <= upper {
+            p.meta_lat[i] += 1
+            return
+        }
+    }
+}   // End of function List_base::latency.
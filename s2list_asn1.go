@@ -0,0 +1,104 @@
+// src/go/s2list_asn1.go   2026-10-17
+// ASN.1 DER encoding of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::MarshalDER
+List_base::UnmarshalDER
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/asn1"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::MarshalDER() encodes the values of the list as an ASN.1 DER
+"SEQUENCE OF". Every value is encoded with asn1.Marshal(), so it must be of a
+type which the encoding/asn1 package supports. An asn1.RawValue is copied
+through unchanged, which allows pre-encoded records to be stored in a list.
+*/
+func (p *List_base) MarshalDER() ([]byte, error) {
+    //--------------------------//
+    //  List_base::MarshalDER   //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::MarshalDER: p == nil")
+    }
+    var body []byte
+    var it List_iter
+    it.Init(p)
+    for {
+        q, E := it.Next()
+        if E != nil {
+            return nil, pushError(E, "List_base::MarshalDER: it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        der, E := asn1.Marshal(q.value)
+        if E != nil {
+            return nil, pushError(E, "List_base::MarshalDER: asn1.Marshal(q.value)")
+        }
+        body = append(body, der...)
+    }
+    seq := asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence,
+        IsCompound: true, Bytes: body}
+    der, E := asn1.Marshal(seq)
+    if E != nil {
+        return nil, pushError(E, "List_base::MarshalDER: asn1.Marshal(seq)")
+    }
+    return der, nil
+}   // End of function List_base::MarshalDER.
+
+/*
+List_base::UnmarshalDER() decodes an ASN.1 DER "SEQUENCE OF" and appends one
+node to the list for each element of the sequence. The function decode converts
+each element to a value. If decode is nil, the elements are appended as
+asn1.RawValue values. The number of appended nodes is returned. If an error
+occurs, the nodes which were appended before the error remain in the list.
+*/
+func (p *List_base) UnmarshalDER(der []byte,
+    decode func(asn1.RawValue) (interface{}, error)) (int, error) {
+    //--------------------------//
+    // List_base::UnmarshalDER  //
+    //--------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::UnmarshalDER: p == nil")
+    }
+    var seq asn1.RawValue
+    rest, E := asn1.Unmarshal(der, &seq)
+    if E != nil {
+        return 0, pushError(E, "List_base::UnmarshalDER: asn1.Unmarshal(der)")
+    }
+    if len(rest) != 0 {
+        return 0, p.fail(ErrBadFormat, nil, "List_base::UnmarshalDER: trailing data")
+    }
+    if seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence ||
+        !seq.IsCompound {
+        return 0, p.fail(ErrBadFormat, nil, "List_base::UnmarshalDER: not a SEQUENCE")
+    }
+    var n int = 0
+    for body := seq.Bytes; len(body) > 0; {
+        var elem asn1.RawValue
+        body, E = asn1.Unmarshal(body, &elem)
+        if E != nil {
+            return n, pushError(E, "List_base::UnmarshalDER: asn1.Unmarshal(body)")
+        }
+        var v interface{} = elem
+        if decode != nil {
+            v, E = decode(elem)
+            if E != nil {
+                return n, pushError(E, "List_base::UnmarshalDER: decode(elem)")
+            }
+        }
+        E = p.AppendValue(v)
+        if E != nil {
+            return n, pushError(E, "List_base::UnmarshalDER: p.AppendValue(v)")
+        }
+        n += 1
+    }
+    return n, nil
+}   // End of function List_base::UnmarshalDER.
@@ -0,0 +1,183 @@
+// src/go/s2list_batch.go   2026-10-17
+// Batch operations on s2list lists with per-element error reporting.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Batch_error::
+Batch_error::Error
+Batch_error::Unwrap
+Batch_error::add
+Batch_error::result
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+List_base::AppendValues
+List_base::FilterInPlace
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "fmt"
+import "strings"
+
+//=============================================================================
+//=============================================================================
+
+/*
+An Element_error records the failure of a batch operation for one element.
+    Index int   // Zero-based index of the element in the batch.
+    Err   error // The cause of the failure.
+*/
+type Element_error struct {
+    Index int   // Zero-based index of the element in the batch.
+    Err   error // The cause of the failure.
+}
+
+/*
+A Batch_error is returned by batch operations which continue after a failure of
+a single element. It lists every element which failed, in order, so that the
+partial progress of the batch is known exactly. A Batch_error unwraps to the
+errors of the individual elements, so errors.Is() and errors.As() look at all
+of them.
+    Op       string          // Name of the batch operation.
+    Failures []Element_error // The failed elements.
+*/
+type Batch_error struct {
+    Op       string          // Name of the batch operation.
+    Failures []Element_error // The failed elements.
+}
+
+/*
+Batch_error::Error() returns a summary of the failures, one line per element.
+*/
+func (p *Batch_error) Error() string {
+    //--------------------------//
+    //    Batch_error::Error    //
+    //--------------------------//
+    if p == nil {
+        return "<nil>"
+    }
+    var sb strings.Builder
+    fmt.Fprintf(&sb, "%s: %d element(s) failed", p.Op, len(p.Failures))
+    for _, f := range p.Failures {
+        fmt.Fprintf(&sb, "\n[%d] %v", f.Index, f.Err)
+    }
+    return sb.String()
+}   // End of function Batch_error::Error.
+
+/*
+Batch_error::Unwrap() returns the errors of the failed elements.
+*/
+func (p *Batch_error) Unwrap() []error {
+    //--------------------------//
+    //   Batch_error::Unwrap    //
+    //--------------------------//
+    if p == nil {
+        return nil
+    }
+    errs := make([]error, len(p.Failures))
+    for i, f := range p.Failures {
+        errs[i] = f.Err
+    }
+    return errs
+}   // End of function Batch_error::Unwrap.
+
+/*
+Batch_error::add() records the failure of element i.
+*/
+func (p *Batch_error) add(i int, E error) {
+    //--------------------------//
+    //     Batch_error::add     //
+    //--------------------------//
+    p.Failures = append(p.Failures, Element_error{Index: i, Err: E})
+}   // End of function Batch_error::add.
+
+/*
+Batch_error::result() returns the batch error, or nil if no element failed.
+*/
+func (p *Batch_error) result() error {
+    //--------------------------//
+    //   Batch_error::result    //
+    //--------------------------//
+    if len(p.Failures) == 0 {
+        return nil
+    }
+    return p
+}   // End of function Batch_error::result.
+
+/*
+List_base::AppendValues() appends the given values to the list, in order. An
+element which cannot be appended is skipped, and the remaining elements are
+still appended. The number of appended values is returned, together with a
+*Batch_error listing the failed elements, or nil if all were appended.
+*/
+func (p *List_base) AppendValues(vs []interface{}) (int, error) {
+    //------------------------------//
+    //   List_base::AppendValues    //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::AppendValues: p == nil")
+    }
+    batch := &Batch_error{Op: "List_base::AppendValues"}
+    var n int = 0
+    for i, v := range vs {
+        E := p.AppendValue(v)
+        if E != nil {
+            batch.add(i, E)
+            continue
+        }
+        n += 1
+    }
+    return n, batch.result()
+}   // End of function List_base::AppendValues.
+
+/*
+List_base::FilterInPlace() removes all nodes from the list for which keep
+returns false, in a single pass. The removed nodes are cast adrift. If keep
+returns an error for a node, the node is kept and the error is recorded with
+the zero-based index of the node in the original list. The number of removed
+nodes is returned, together with a *Batch_error listing the failed elements,
+or nil if there were no failures.
+*/
+func (p *List_base) FilterInPlace(keep func(interface{}) (bool, error)) (int, error) {
+    //------------------------------//
+    //   List_base::FilterInPlace   //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::FilterInPlace: p == nil")
+    }
+    if keep == nil {
+        return 0, p.fail(ErrNilArgument, nil, "List_base::FilterInPlace: keep == nil")
+    }
+    if p.first == nil {
+        return 0, nil
+    }
+    if p.last == nil {
+        return 0, p.fail(ErrCorruptList, nil, "List_base::FilterInPlace: p.first != p.last == nil")
+    }
+    batch := &Batch_error{Op: "List_base::FilterInPlace"}
+    var n int = 0
+    var it List_iter
+    it.Init(p)
+    for i := 0; ; i += 1 {
+        q, E := it.Next()
+        if E != nil {
+            return n, pushError(E, "List_base::FilterInPlace: it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        ok, E := keep(q.value)
+        if E != nil {
+            batch.add(i, E)
+            continue
+        }
+        if ok {
+            continue
+        }
+        _, E = it.RemoveCurrent()
+        if E != nil {
+            return n, pushError(E, "List_base::FilterInPlace: it.RemoveCurrent()")
+        }
+        n += 1
+    }
+    return n, batch.result()
+}   // End of function List_base::FilterInPlace.
@@ -0,0 +1,250 @@
+// src/go/s2list_blist.go   2026-10-17
+// Sorted two-level lists of node blocks with a top index.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+NewB_list
+B_list::
+B_list::Length
+B_list::Insert
+B_list::Find
+B_list::Remove
+B_list::All
+B_list::split
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "iter"
+import "sort"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A B_list holds values in sorted order in a sequence of blocks, each of which is
+a short sorted list of nodes. The top index is a slice of the blocks, which is
+searched by bisection. So insertion and search cost O(log n + B), where B is
+the block size, and ordered iteration costs O(n). This is a middle ground
+between a plain List_base and a skip list for medium-sized ordered datasets.
+    less   func(a, b interface{}) bool // The ordering of the values.
+    size   int                         // Maximum block length before a split.
+    blocks []*List_base                // The non-empty blocks, in order.
+    n      int                         // Total number of nodes.
+The blocks are read-only lists, so the values of the nodes cannot be changed
+with List_node::SetValue(), which could break the ordering. The next-pointer of
+the last node of a block is nil, so List_node::GetNext() does not cross block
+boundaries. Use B_list::All() for ordered traversal.
+*/
+type B_list struct {
+    //----------------------//
+    //       B_list::       //
+    //----------------------//
+    less   func(a, b interface{}) bool // The ordering of the values.
+    size   int                         // Maximum block length before a split.
+    blocks []*List_base                // The non-empty blocks, in order.
+    n      int                         // Total number of nodes.
+}
+
+/*
+NewB_list() returns an empty B_list with the given ordering. Blocks are split in
+half when they grow beyond twice the block size. A block size of zero or less
+selects the default of 64. Choose about sqrt(n) for datasets of n values. If
+less is nil, B_list::Insert() and B_list::Find() order the values with the
+Less function which was registered for the type of their argument, and fail if
+that type has none.
+*/
+func NewB_list(less func(a, b interface{}) bool, block int) *B_list {
+    //----------------------//
+    //       NewB_list      //
+    //----------------------//
+    if block <= 0 {
+        block = 64
+    }
+    return &B_list{less: less, size: block}
+}   // End of function NewB_list.
+
+/*
+B_list::Length() returns the number of values in the list.
+*/
+func (p *B_list) Length() int {
+    //----------------------//
+    //    B_list::Length    //
+    //----------------------//
+    if p == nil {
+        nilReceiver("B_list::Length")
+        return 0
+    }
+    return p.n
+}   // End of function B_list::Length.
+
+/*
+B_list::Insert() inserts the value v in sorted order, after any equal values,
+and returns the new node.
+*/
+func (p *B_list) Insert(v interface{}) (*List_node, error) {
+    //----------------------//
+    //    B_list::Insert    //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "B_list::Insert: p == nil")
+    }
+    less, E := resolveLess("B_list::Insert", p.less, v)
+    if E != nil {
+        return nil, E
+    }
+    // The last block whose first value is not greater than v.
+    i := sort.Search(len(p.blocks), func(i int) bool {
+        return less(v, p.blocks[i].first.value)
+    }) - 1
+    if i < 0 {
+        i = 0
+    }
+    if len(p.blocks) == 0 {
+        blk := new(List_base)
+        blk.readonly = true
+        p.blocks = append(p.blocks, blk)
+    }
+    blk := p.blocks[i]
+    var prev *List_node = nil
+    for q := blk.first; q != nil; q = q.next {
+        if less(v, q.value) {
+            break
+        }
+        prev = q
+    }
+    pnode := blk.newNode(v)
+    blk.modcount += 1
+    blk.insertAfter(prev, pnode)
+    p.n += 1
+    p.split(i)
+    return pnode, nil
+}   // End of function B_list::Insert.
+
+/*
+B_list::Find() returns the first node whose value is equivalent to v, which
+means that neither value is less than the other. The return value is nil if
+there is no such node.
+*/
+func (p *B_list) Find(v interface{}) (*List_node, error) {
+    //----------------------//
+    //     B_list::Find     //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "B_list::Find: p == nil")
+    }
+    less, E := resolveLess("B_list::Find", p.less, v)
+    if E != nil {
+        return nil, E
+    }
+    // The first block whose last value is not less than v.
+    i := sort.Search(len(p.blocks), func(i int) bool {
+        return !less(p.blocks[i].last.value, v)
+    })
+    if i == len(p.blocks) {
+        return nil, nil
+    }
+    for q := p.blocks[i].first; q != nil; q = q.next {
+        if !less(q.value, v) {
+            if less(v, q.value) {
+                return nil, nil
+            }
+            return q, nil
+        }
+    }
+    return nil, nil
+}   // End of function B_list::Find.
+
+/*
+B_list::Remove() removes the node q from the list. The node is cast adrift.
+*/
+func (p *B_list) Remove(q *List_node) error {
+    //----------------------//
+    //    B_list::Remove    //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "B_list::Remove: p == nil")
+    }
+    if q == nil {
+        return newError(ErrNilArgument, "B_list::Remove: q == nil")
+    }
+    for i, blk := range p.blocks {
+        if q.base != blk {
+            continue
+        }
+        prev, E := blk.pred("B_list::Remove", q)
+        if E != nil {
+            return E
+        }
+        blk.modcount += 1
+        blk.removeAfter(prev, q)
+        p.n -= 1
+        if blk.first == nil {
+            p.blocks = append(p.blocks[:i], p.blocks[i+1:]...)
+        }
+        return nil
+    }
+    return newError(ErrNotMember, "B_list::Remove: q is not in p")
+}   // End of function B_list::Remove.
+
+/*
+B_list::All() returns a sequence of the nodes in sorted order, for use in a
+range-over-func loop. The list must not be modified during the loop.
+*/
+func (p *B_list) All() iter.Seq2[*List_node, error] {
+    //----------------------//
+    //      B_list::All     //
+    //----------------------//
+    return func(yield func(*List_node, error) bool) {
+        if p == nil {
+            yield(nil, newError(ErrNilReceiver, "B_list::All: p == nil"))
+            return
+        }
+        for _, blk := range p.blocks {
+            var it List_iter
+            it.Init(blk)
+            for {
+                q, E := it.Next()
+                if E != nil {
+                    yield(nil, pushError(E, "B_list::All: it.Next()"))
+                    return
+                }
+                if q == nil {
+                    break
+                }
+                if !yield(q, nil) {
+                    return
+                }
+            }
+        }
+    }
+}   // End of function B_list::All.
+
+/*
+B_list::split() is a private member function for internal use in this package.
+It splits block i in half if it is longer than twice the block size.
+*/
+func (p *B_list) split(i int) {
+    //----------------------//
+    //    B_list::split     //
+    //----------------------//
+    blk := p.blocks[i]
+    var n int = 0
+    for q := blk.first; q != nil; q = q.next {
+        n += 1
+    }
+    if n <= 2*p.size {
+        return
+    }
+    mid := blk.nth(n/2 - 1)
+    first, last := mid.next, blk.last
+    mid.next = nil
+    blk.last = mid
+    blk.modcount += 1
+    nb := new(List_base)
+    nb.readonly = true
+    nb.putChain(first, last)
+    p.blocks = append(p.blocks, nil)
+    copy(p.blocks[i+2:], p.blocks[i+1:])
+    p.blocks[i+1] = nb
+}   // End of function B_list::split.
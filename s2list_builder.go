@@ -0,0 +1,138 @@
+// src/go/s2list_builder.go   2026-10-17
+// Construction of s2list lists by fluent chaining.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Builder::
+NewBuilder
+Builder::Add
+Builder::AddAll
+Builder::Build
+Builder::MustBuild
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "strconv"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Builder constructs a list from values in one expression, such as
+    l, E := NewBuilder().Add(1).Add(2).AddAll(xs).Build()
+The methods of a Builder do not return errors. The first error is kept, later
+values are ignored, and Builder::Build() reports the error. This is convenient
+for lists in table-driven tests. The zero value is a builder of a list without
+options.
+    opts []Option   // The options of the list.
+    list *List_base // The list which is being built, or nil.
+    n    int        // The number of values which have been added.
+    err  error      // The first error, or nil.
+*/
+type Builder struct {
+    //----------------------//
+    //       Builder::      //
+    //----------------------//
+    opts []Option   // The options of the list.
+    list *List_base // The list which is being built, or nil.
+    n    int        // The number of values which have been added.
+    err  error      // The first error, or nil.
+}
+
+/*
+NewBuilder() returns a builder of a list which is configured by the options.
+An invalid option is reported by Builder::Build().
+*/
+func NewBuilder(opts ...Option) *Builder {
+    //----------------------//
+    //      NewBuilder      //
+    //----------------------//
+    return &Builder{opts: opts}
+}   // End of function NewBuilder.
+
+/*
+Builder::Add() appends a new node with the value v to the list, and returns
+the builder.
+*/
+func (p *Builder) Add(v interface{}) *Builder {
+    //----------------------//
+    //     Builder::Add     //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Builder::Add")
+        return nil
+    }
+    if p.err != nil {
+        return p
+    }
+    if p.list == nil {
+        p.list = new(List_base)
+        p.err = p.list.apply("Builder::Add", p.opts)
+        if p.err != nil {
+            return p
+        }
+    }
+    E := p.list.AppendValue(v)
+    if E != nil {
+        p.err = pushError(E, "Builder::Add: value " + strconv.Itoa(p.n))
+    }
+    p.n += 1
+    return p
+}   // End of function Builder::Add.
+
+/*
+Builder::AddAll() appends a new node for each of the values, in order, and
+returns the builder.
+*/
+func (p *Builder) AddAll(values []interface{}) *Builder {
+    //----------------------//
+    //    Builder::AddAll   //
+    //----------------------//
+    for _, v := range values {
+        p = p.Add(v)
+    }
+    return p
+}   // End of function Builder::AddAll.
+
+/*
+Builder::Build() returns the list, or the first error of the builder.
+Afterwards the builder is empty again, with the same options, so that it can
+build another list.
+*/
+func (p *Builder) Build() (*List_base, error) {
+    //----------------------//
+    //    Builder::Build    //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "Builder::Build: p == nil")
+    }
+    list, E := p.list, p.err
+    p.list, p.n, p.err = nil, 0, nil
+    if E != nil {
+        return nil, E
+    }
+    if list == nil {
+        list = new(List_base)
+        E = list.apply("Builder::Build", p.opts)
+        if E != nil {
+            return nil, E
+        }
+    }
+    return list, nil
+}   // End of function Builder::Build.
+
+/*
+Builder::MustBuild() is like Builder::Build(), but it returns only the list and
+panics if an error is returned.
+*/
+func (p *Builder) MustBuild() *List_base {
+    //----------------------//
+    //  Builder::MustBuild  //
+    //----------------------//
+    list, E := p.Build()
+    if E != nil {
+        panic(E)
+    }
+    return list
+}   // End of function Builder::MustBuild.
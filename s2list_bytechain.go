@@ -0,0 +1,250 @@
+// src/go/s2list_bytechain.go   2026-10-17
+// Byte queues of chunks in s2list lists with io adapters.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+ByteChain::
+ByteChain::Len
+ByteChain::Write
+ByteChain::WriteString
+ByteChain::AppendChunk
+ByteChain::Read
+ByteChain::WriteTo
+ByteChain::Bytes
+ByteChain::Reset
+ByteChain::consume
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "io"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A ByteChain is a queue of bytes which is stored in chunks, one []byte chunk in
+each node of a list. Writes append chunks, and reads drain bytes from the
+front, so a message can be assembled from pieces and then sent with io.Copy(),
+which writes each chunk directly through ByteChain::WriteTo(). The total length
+is kept, so ByteChain::Len() costs O(1). Unlike a Rope, a ByteChain has no
+random access, and unlike List_base::Reader(), reading removes the bytes. A
+ByteChain is not safe for concurrent use. The zero value is an empty chain.
+    chunks List_base // The chunks, which are non-empty []byte values.
+    off    int       // The number of bytes of the first chunk already read.
+    n      int       // The number of unread bytes.
+*/
+type ByteChain struct {
+    //----------------------//
+    //      ByteChain::     //
+    //----------------------//
+    chunks List_base // The chunks, which are non-empty []byte values.
+    off    int       // The number of bytes of the first chunk already read.
+    n      int       // The number of unread bytes.
+}
+
+// A ByteChain is a reader and writer of bytes.
+var _ io.ReadWriter = (*ByteChain)(nil)
+var _ io.WriterTo = (*ByteChain)(nil)
+var _ io.StringWriter = (*ByteChain)(nil)
+
+/*
+ByteChain::Len() returns the number of unread bytes in the chain.
+*/
+func (p *ByteChain) Len() int {
+    //----------------------//
+    //    ByteChain::Len    //
+    //----------------------//
+    if p == nil {
+        nilReceiver("ByteChain::Len")
+        return 0
+    }
+    return p.n
+}   // End of function ByteChain::Len.
+
+/*
+ByteChain::Write() appends a copy of the bytes b to the chain as one chunk, as
+io.Writer requires. It never fails for a non-nil chain.
+*/
+func (p *ByteChain) Write(b []byte) (int, error) {
+    //----------------------//
+    //   ByteChain::Write   //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "ByteChain::Write: p == nil")
+    }
+    if len(b) == 0 {
+        return 0, nil
+    }
+    E := p.AppendChunk(append([]byte(nil), b...))
+    if E != nil {
+        return 0, pushError(E, "ByteChain::Write: p.AppendChunk()")
+    }
+    return len(b), nil
+}   // End of function ByteChain::Write.
+
+/*
+ByteChain::WriteString() appends the bytes of the string s to the chain as one
+chunk, as io.StringWriter requires.
+*/
+func (p *ByteChain) WriteString(s string) (int, error) {
+    //------------------------------//
+    //    ByteChain::WriteString    //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "ByteChain::WriteString: p == nil")
+    }
+    if len(s) == 0 {
+        return 0, nil
+    }
+    E := p.AppendChunk([]byte(s))
+    if E != nil {
+        return 0, pushError(E, "ByteChain::WriteString: p.AppendChunk()")
+    }
+    return len(s), nil
+}   // End of function ByteChain::WriteString.
+
+/*
+ByteChain::AppendChunk() appends the slice b to the chain as one chunk without
+copying it, such as a header which was encoded for this message. The caller
+must not modify b afterwards. An empty slice is ignored.
+*/
+func (p *ByteChain) AppendChunk(b []byte) error {
+    //------------------------------//
+    //    ByteChain::AppendChunk    //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "ByteChain::AppendChunk: p == nil")
+    }
+    if len(b) == 0 {
+        return nil
+    }
+    E := p.chunks.AppendValue(b)
+    if E != nil {
+        return pushError(E, "ByteChain::AppendChunk: p.chunks.AppendValue(b)")
+    }
+    p.n += len(b)
+    return nil
+}   // End of function ByteChain::AppendChunk.
+
+/*
+ByteChain::Read() removes up to len(b) bytes from the front of the chain and
+copies them into b, as io.Reader requires. When the chain is empty, io.EOF is
+returned, and later writes can be read again.
+*/
+func (p *ByteChain) Read(b []byte) (int, error) {
+    //----------------------//
+    //    ByteChain::Read   //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "ByteChain::Read: p == nil")
+    }
+    if p.n == 0 {
+        if len(b) == 0 {
+            return 0, nil
+        }
+        return 0, io.EOF
+    }
+    var n int = 0
+    for n < len(b) && p.n > 0 {
+        chunk := p.chunks.first.value.([]byte)[p.off:]
+        k := copy(b[n:], chunk)
+        n += k
+        E := p.consume(k)
+        if E != nil {
+            return n, pushError(E, "ByteChain::Read: p.consume()")
+        }
+    }
+    return n, nil
+}   // End of function ByteChain::Read.
+
+/*
+ByteChain::WriteTo() writes the bytes of the chain to w, one write per chunk,
+and removes them, as io.WriterTo requires. If a write fails, the bytes which
+were not written remain in the chain.
+*/
+func (p *ByteChain) WriteTo(w io.Writer) (int64, error) {
+    //--------------------------//
+    //    ByteChain::WriteTo    //
+    //--------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "ByteChain::WriteTo: p == nil")
+    }
+    var n int64 = 0
+    for p.n > 0 {
+        chunk := p.chunks.first.value.([]byte)[p.off:]
+        k, E := w.Write(chunk)
+        n += int64(k)
+        E2 := p.consume(k)
+        if E != nil {
+            return n, E
+        }
+        if E2 != nil {
+            return n, pushError(E2, "ByteChain::WriteTo: p.consume()")
+        }
+        if k < len(chunk) {
+            return n, io.ErrShortWrite
+        }
+    }
+    return n, nil
+}   // End of function ByteChain::WriteTo.
+
+/*
+ByteChain::Bytes() returns a copy of the unread bytes of the chain, without
+removing them.
+*/
+func (p *ByteChain) Bytes() []byte {
+    //----------------------//
+    //   ByteChain::Bytes   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("ByteChain::Bytes")
+        return nil
+    }
+    b := make([]byte, 0, p.n)
+    off := p.off
+    for q := p.chunks.first; q != nil; q = q.next {
+        b = append(b, q.value.([]byte)[off:]...)
+        off = 0
+    }
+    return b
+}   // End of function ByteChain::Bytes.
+
+/*
+ByteChain::Reset() removes all bytes from the chain.
+*/
+func (p *ByteChain) Reset() error {
+    //----------------------//
+    //   ByteChain::Reset   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "ByteChain::Reset: p == nil")
+    }
+    E := p.chunks.Clear()
+    if E != nil {
+        return pushError(E, "ByteChain::Reset: p.chunks.Clear()")
+    }
+    p.off = 0
+    p.n = 0
+    return nil
+}   // End of function ByteChain::Reset.
+
+/*
+ByteChain::consume() is a private member function for internal use in this
+package.
+It removes k bytes of the first chunk, which must have at least k unread bytes,
+and pops the chunk when it has been read completely.
+*/
+func (p *ByteChain) consume(k int) error {
+    //--------------------------//
+    //    ByteChain::consume    //
+    //--------------------------//
+    p.off += k
+    p.n -= k
+    if p.off < len(p.chunks.first.value.([]byte)) {
+        return nil
+    }
+    p.off = 0
+    _, E := p.chunks.Popfirst()
+    return E
+}   // End of function ByteChain::consume.
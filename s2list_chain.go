@@ -0,0 +1,441 @@
+// src/go/s2list_chain.go   2026-10-17
+// Detached chains of s2list nodes for handing work between goroutines.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::DetachAll
+List_base::AttachAll
+List_base::TransferAllTo
+List_base::StealFirstN
+SwapContents
+List_base::takeChain
+List_base::putChain
+List_base::joinChain
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+List_chain::
+List_chain::Base
+List_chain::Length
+List_chain::Take
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A List_chain is a chain of nodes which has been detached from a list by
+List_base::DetachAll(). The chain has a private read-only list-base, so no node
+can be added to it, and its nodes cannot be appended to other lists. A consumer
+goroutine which has received a chain from a producer owns it exclusively, and it
+can read and consume it without locking. Nodes which have not been consumed can
+be re-injected into a list with List_base::AttachAll().
+    base List_base // The read-only base of the detached nodes.
+*/
+type List_chain struct {
+    //----------------------//
+    //     List_chain::     //
+    //----------------------//
+    base List_base // The read-only base of the detached nodes.
+}
+
+/*
+List_base::DetachAll() removes all nodes from the list in O(n) and returns them
+as a chain in the same order. The list is empty afterwards. Cursors registered
+with the list become invalid.
+*/
+func (p *List_base) DetachAll() (*List_chain, error) {
+    //--------------------------//
+    //   List_base::DetachAll   //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::DetachAll: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::DetachAll", time.Now())
+    }
+    if p.first != nil && p.last == nil {
+        return nil, p.fail(ErrCorruptList, nil, "List_base::DetachAll: p.first != p.last == nil")
+    }
+    E := p.modify("List_base::DetachAll")
+    if E != nil {
+        return nil, E
+    }
+    c := new(List_chain)
+    c.base.readonly = true
+    first, last := p.takeChain()
+    c.base.putChain(first, last)
+    return c, nil
+}   // End of function List_base::DetachAll.
+
+/*
+List_base::AttachAll() appends all remaining nodes of the chain to the list, in
+order, in O(n). The chain is empty afterwards.
+*/
+func (p *List_base) AttachAll(c *List_chain) error {
+    //--------------------------//
+    //   List_base::AttachAll   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::AttachAll: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::AttachAll", time.Now())
+    }
+    if c == nil {
+        return nil
+    }
+    if c.base.first == nil {
+        return nil
+    }
+    E := p.checkChain("List_base::AttachAll", c.base.first, c.base.last)
+    if E != nil {
+        return E
+    }
+    E = p.modify("List_base::AttachAll")
+    if E != nil {
+        return E
+    }
+    // The chain base is read-only to everyone except this package.
+    c.base.readonly = false
+    E = c.base.modify("List_base::AttachAll")
+    c.base.readonly = true
+    if E != nil {
+        return E
+    }
+    first, last := c.base.takeChain()
+    p.joinChain(first, last)
+    p.putChain(first, last)
+    if p.max_len > 0 {
+        return p.trim("List_base::AttachAll", false, true)
+    }
+    return nil
+}   // End of function List_base::AttachAll.
+
+/*
+List_base::TransferAllTo() moves all nodes of the list to the end of the list
+dst, in order, in a single pass which retargets their base-pointers. The list
+is empty afterwards. Cursors registered with the list become invalid. This
+hands a full batch of work from a producer list to a consumer list.
+*/
+func (p *List_base) TransferAllTo(dst *List_base) error {
+    //------------------------------//
+    //   List_base::TransferAllTo   //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::TransferAllTo: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::TransferAllTo", time.Now())
+    }
+    if dst == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::TransferAllTo: dst == nil")
+    }
+    if dst == p || p.first == nil {
+        return nil
+    }
+    if p.last == nil {
+        return p.fail(ErrCorruptList, nil, "List_base::TransferAllTo: p.first != p.last == nil")
+    }
+    E := dst.checkChain("List_base::TransferAllTo", p.first, p.last)
+    if E != nil {
+        return E
+    }
+    E = dst.modify("List_base::TransferAllTo")
+    if E != nil {
+        return E
+    }
+    E = p.modify("List_base::TransferAllTo")
+    if E != nil {
+        return E
+    }
+    first, last := p.takeChain()
+    dst.joinChain(first, last)
+    dst.putChain(first, last)
+    if dst.max_len > 0 {
+        return dst.trim("List_base::TransferAllTo", false, true)
+    }
+    return nil
+}   // End of function List_base::TransferAllTo.
+
+/*
+List_base::StealFirstN() moves up to n nodes from the front of the list to the
+end of the list dst, in order, and returns the number of moved nodes. The
+nodes are moved in one operation, which costs O(n), instead of n pairs of
+List_base::Popfirst() and List_base::Append(). Cursors registered with the
+list at moved nodes are advanced to the new first node. This is the transfer
+which a work-stealing scheduler needs. Lists which are shared by goroutines
+should use SyncList::StealFirstN(), which holds the locks of both lists.
+*/
+func (p *List_base) StealFirstN(n int, dst *List_base) (int, error) {
+    //------------------------------//
+    //    List_base::StealFirstN    //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::StealFirstN: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::StealFirstN", time.Now())
+    }
+    if dst == nil {
+        return 0, p.fail(ErrNilArgument, nil, "List_base::StealFirstN: dst == nil")
+    }
+    if n < 0 {
+        return 0, p.fail(ErrInvalidArgument, nil, "List_base::StealFirstN: n < 0")
+    }
+    if dst == p || n == 0 || p.first == nil {
+        return 0, nil
+    }
+    if p.last == nil {
+        return 0, p.fail(ErrCorruptList, nil, "List_base::StealFirstN: p.first != p.last == nil")
+    }
+    // Find the last node to be moved.
+    first := p.first
+    last := first
+    var k int = 1
+    for ; k < n && last != p.last; k += 1 {
+        last = last.next
+    }
+    E := dst.checkChain("List_base::StealFirstN", first, last)
+    if E != nil {
+        return 0, E
+    }
+    E = dst.modify("List_base::StealFirstN")
+    if E != nil {
+        return 0, E
+    }
+    E = p.modify("List_base::StealFirstN")
+    if E != nil {
+        return 0, E
+    }
+    if len(p.cursors) > 0 {
+        for q := first; ; q = q.next {
+            p.leaving(q)
+            if q == last {
+                break
+            }
+        }
+    }
+    if last == p.last {
+        p.first = nil
+        p.last = nil
+    } else {
+        p.first = last.next
+    }
+    if p.watchers != nil || p.hooks != nil {
+        for q := first; ; q = q.next {
+            p.reportRemove(q)
+            if q == last {
+                break
+            }
+        }
+    }
+    dst.joinChain(first, last)
+    dst.putChain(first, last)
+    if dst.max_len > 0 {
+        return k, dst.trim("List_base::StealFirstN", false, true)
+    }
+    return k, nil
+}   // End of function List_base::StealFirstN.
+
+/*
+SwapContents() exchanges the nodes of the lists a and b, and retargets their
+base-pointers, in a single pass over both lists. This supports double
+buffering, where producers fill one list while a consumer drains the other.
+Cursors registered with either list become invalid.
+*/
+func SwapContents(a, b *List_base) error {
+    //----------------------//
+    //     SwapContents     //
+    //----------------------//
+    if a == nil {
+        return newError(ErrNilArgument, "SwapContents: a == nil")
+    }
+    if b == nil {
+        return a.fail(ErrNilArgument, nil, "SwapContents: b == nil")
+    }
+    if a == b || (a.first == nil && b.first == nil) {
+        return nil
+    }
+    if a.first != nil && a.last == nil {
+        return a.fail(ErrCorruptList, nil, "SwapContents: a.first != a.last == nil")
+    }
+    if b.first != nil && b.last == nil {
+        return b.fail(ErrCorruptList, nil, "SwapContents: b.first != b.last == nil")
+    }
+    E := a.checkChain("SwapContents", b.first, b.last)
+    if E != nil {
+        return E
+    }
+    E = b.checkChain("SwapContents", a.first, a.last)
+    if E != nil {
+        return E
+    }
+    E = a.modify("SwapContents")
+    if E != nil {
+        return E
+    }
+    E = b.modify("SwapContents")
+    if E != nil {
+        return E
+    }
+    first_a, last_a := a.takeChain()
+    first_b, last_b := b.takeChain()
+    a.joinChain(first_b, last_b)
+    b.joinChain(first_a, last_a)
+    a.putChain(first_b, last_b)
+    b.putChain(first_a, last_a)
+    if a.max_len > 0 {
+        E = a.trim("SwapContents", false, true)
+        if E != nil {
+            return E
+        }
+    }
+    if b.max_len > 0 {
+        return b.trim("SwapContents", false, true)
+    }
+    return nil
+}   // End of function SwapContents.
+
+/*
+List_base::takeChain() is a private member function for internal use in this
+package.
+It empties the list and returns the first and last nodes of its former chain.
+The base-pointers of the nodes are not changed. Cursors registered with the list
+become invalid. The nodes are reported as removed to the watchers and the hooks,
+but their values are not released. The caller must have called
+List_base::modify().
+*/
+func (p *List_base) takeChain() (*List_node, *List_node) {
+    //--------------------------//
+    //   List_base::takeChain   //
+    //--------------------------//
+    first, last := p.first, p.last
+    p.first = nil
+    p.last = nil
+    for c := range p.cursors {
+        c.node = nil
+    }
+    if p.watchers != nil || p.hooks != nil {
+        for q := first; q != nil; q = q.next {
+            p.reportRemove(q)
+            if q == last {
+                break
+            }
+        }
+    }
+    return first, last
+}   // End of function List_base::takeChain.
+
+/*
+List_base::putChain() is a private member function for internal use in this
+package.
+It appends the chain of nodes from first to last to the list, and sets their
+base-pointers to the list. The nodes are reported as appended to the watchers
+and the hooks. The caller must have called List_base::modify(), and
+List_base::joinChain() if the nodes come from another list.
+*/
+func (p *List_base) putChain(first, last *List_node) {
+    //--------------------------//
+    //   List_base::putChain    //
+    //--------------------------//
+    if first == nil {
+        return
+    }
+    for q := first; q != nil; q = q.next {
+        q.base = p
+        if q == last {
+            break
+        }
+    }
+    last.next = nil
+    if p.last != nil {
+        p.last.next = first
+    } else {
+        p.first = first
+        if p.ready != nil {
+            p.filled()
+        }
+    }
+    p.last = last
+    if p.watchers != nil || p.hooks != nil {
+        for q := first; q != nil; q = q.next {
+            p.joined(q, Change_append, nil)
+        }
+    }
+}   // End of function List_base::putChain.
+
+/*
+List_base::joinChain() is a private member function for internal use in this
+package.
+It prepares the chain of nodes from first to last, which is moved into the list
+from another list, with List_base::joining(), like a single node which is
+appended. So the nodes are stamped if metadata is enabled, and their values are
+interned if the list has an interner. The caller must have called
+List_base::modify() and List_base::checkChain().
+*/
+func (p *List_base) joinChain(first, last *List_node) {
+    //--------------------------//
+    //   List_base::joinChain   //
+    //--------------------------//
+    if !p.meta_on && p.release == nil && p.interner == nil {
+        return
+    }
+    for q := first; q != nil; q = q.next {
+        p.joining(q)
+        if q == last {
+            break
+        }
+    }
+}   // End of function List_base::joinChain.
+
+/*
+List_chain::Base() returns the read-only list-base of the chain. It can be used
+with List_iter and all other non-mutating List_base methods.
+*/
+func (p *List_chain) Base() *List_base {
+    //----------------------//
+    //   List_chain::Base   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_chain::Base")
+        return nil
+    }
+    return &p.base
+}   // End of function List_chain::Base.
+
+/*
+List_chain::Length() returns the number of nodes remaining in the chain.
+*/
+func (p *List_chain) Length() int {
+    //----------------------//
+    //  List_chain::Length  //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_chain::Length")
+        return 0
+    }
+    return p.base.Length()
+}   // End of function List_chain::Length.
+
+/*
+List_chain::Take() removes the first node from the chain and returns it to the
+caller. The return value is nil if the chain is empty.
+*/
+func (p *List_chain) Take() (*List_node, error) {
+    //----------------------//
+    //   List_chain::Take   //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_chain::Take: p == nil")
+    }
+    p.base.readonly = false
+    q, E := p.base.Popfirst()
+    p.base.readonly = true
+    if E != nil {
+        return nil, pushError(E, "List_chain::Take: p.base.Popfirst()")
+    }
+    return q, nil
+}   // End of function List_chain::Take.
@@ -0,0 +1,94 @@
+// src/go/s2list_check.go   2026-10-17
+// Integrity-check levels of the basic s2list operations.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SetCheckLevel
+List_base::CheckLevel
+List_base::fullCheck
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "fmt"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Check_level selects how much validation List_base::Append(),
+List_base::Remove(), List_base::Found() and List_iter::Next() perform.
+    Check_fast  the O(1) base-pointer checks of each call (the default)
+    Check_off   no base-pointer checks
+    Check_full  the base-pointer checks, and a verification of the whole list
+At Check_off, the caller guarantees that nodes are never appended to two lists,
+and that only members of the list are passed to Remove() and Found(). A
+violation is no longer detected, and it may corrupt the lists. Found() still
+returns false for a node of another list, and Remove() still fails for it, but
+only after searching the whole list. List_iter::Next() still detects concurrent
+modification.
+At Check_full, each call first verifies the invariant of the whole list with
+List_base::Validate(), which costs O(n). List_iter::Next() does this only at
+the start of an iteration. This level is intended for tests.
+*/
+type Check_level int
+
+const (
+    Check_fast Check_level = iota
+    Check_off
+    Check_full
+)
+
+/*
+List_base::SetCheckLevel() sets the integrity-check level of the list.
+*/
+func (p *List_base) SetCheckLevel(level Check_level) error {
+    //------------------------------//
+    //   List_base::SetCheckLevel   //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetCheckLevel: p == nil")
+    }
+    if level < Check_fast || level > Check_full {
+        return p.fail(ErrInvalidArgument, nil, "List_base::SetCheckLevel: unknown level")
+    }
+    p.check = level
+    return nil
+}   // End of function List_base::SetCheckLevel.
+
+/*
+List_base::CheckLevel() returns the integrity-check level of the list.
+*/
+func (p *List_base) CheckLevel() Check_level {
+    //--------------------------//
+    //  List_base::CheckLevel   //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("List_base::CheckLevel")
+        return Check_fast
+    }
+    return p.check
+}   // End of function List_base::CheckLevel.
+
+/*
+List_base::fullCheck() is a private member function for internal use in this
+package.
+It verifies the invariant of the whole list with List_base::Validate(), and
+returns an error for the first defect. The argument is the name of the calling
+method, for error messages.
+*/
+func (p *List_base) fullCheck(fn string) error {
+    //--------------------------//
+    //   List_base::fullCheck   //
+    //--------------------------//
+    r, E := p.Validate()
+    if E != nil {
+        return pushError(E, fn+": p.Validate()")
+    }
+    if !r.OK() {
+        d := r.Defects[0]
+        return p.corrupt(ErrCorruptList, d.Node, fmt.Sprintf("%s: invariant violated: %v at index %d",
+            fn, d.Kind, d.Index))
+    }
+    return nil
+}   // End of function List_base::fullCheck.
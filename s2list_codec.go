@@ -0,0 +1,234 @@
+// src/go/s2list_codec.go   2026-10-17
+// Pluggable serialization formats of whole s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+RegisterCodec
+LookupCodec
+CodecNames
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+JSON_codec::Encode
+JSON_codec::Decode
+Gob_codec::Encode
+Gob_codec::Decode
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+List_base::valueSlice
+listFromValues
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/gob"
+import "encoding/json"
+import "io"
+import "sort"
+import "sync"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Codec writes a whole list to a stream and reads it back, in some
+serialization format. Only the values of the list are stored, in order. Unlike
+a Value_codec, which converts single values for the binary layouts of this
+package, a Codec determines the complete format, so that lists can be exchanged
+with other programs. Codecs for formats such as msgpack or protobuf can be
+registered with RegisterCodec().
+*/
+type Codec interface {
+    Encode(w io.Writer, p *List_base) error
+    Decode(r io.Reader) (*List_base, error)
+}
+
+/*
+JSON_codec is a Codec which stores a list as a JSON array of its values. The
+values are encoded with package encoding/json. When they are decoded, JSON
+numbers become float64 values, or json.Number values if UseNumber is true, and
+objects become map[string]interface{} values, so only lists of strings,
+booleans and nil survive a round trip with the same types.
+    UseNumber bool // Decode numbers as json.Number.
+*/
+type JSON_codec struct {
+    UseNumber bool // Decode numbers as json.Number.
+}
+
+/*
+Gob_codec is a Codec which stores a list as a gob stream of a []interface{}
+slice of its values. The concrete types of the values must be registered with
+gob.Register() in both the encoding and the decoding program. Values keep their
+types in a round trip. Nil values cannot be encoded by package encoding/gob.
+*/
+type Gob_codec struct{}
+
+/*
+The registered codecs, by name. "json" and "gob" are registered by this
+package.
+*/
+var codecs_mu sync.RWMutex
+var codecs = map[string]Codec{
+    "json": JSON_codec{},
+    "gob":  Gob_codec{},
+}
+
+/*
+RegisterCodec() registers the codec c under the name, such as "msgpack". A
+later registration for the same name replaces the earlier one, including the
+built-in codecs. This is normally called once from an init function.
+*/
+func RegisterCodec(name string, c Codec) error {
+    //------------------------------//
+    //        RegisterCodec         //
+    //------------------------------//
+    if name == "" {
+        return newError(ErrInvalidArgument, "RegisterCodec: name == \"\"")
+    }
+    if c == nil {
+        return newError(ErrNilArgument, "RegisterCodec: c == nil")
+    }
+    codecs_mu.Lock()
+    defer codecs_mu.Unlock()
+    codecs[name] = c
+    return nil
+}   // End of function RegisterCodec.
+
+/*
+LookupCodec() returns the codec which is registered under the name, and true,
+or false if there is none.
+*/
+func LookupCodec(name string) (Codec, bool) {
+    //------------------------------//
+    //         LookupCodec          //
+    //------------------------------//
+    codecs_mu.RLock()
+    defer codecs_mu.RUnlock()
+    c, ok := codecs[name]
+    return c, ok
+}   // End of function LookupCodec.
+
+/*
+CodecNames() returns the names of the registered codecs in sorted order.
+*/
+func CodecNames() []string {
+    //------------------------------//
+    //          CodecNames          //
+    //------------------------------//
+    codecs_mu.RLock()
+    defer codecs_mu.RUnlock()
+    names := make([]string, 0, len(codecs))
+    for name := range codecs {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}   // End of function CodecNames.
+
+/*
+JSON_codec::Encode() writes the values of the list to w as a JSON array,
+followed by a newline.
+*/
+func (c JSON_codec) Encode(w io.Writer, p *List_base) error {
+    //------------------------------//
+    //      JSON_codec::Encode      //
+    //------------------------------//
+    values, E := p.valueSlice("JSON_codec::Encode")
+    if E != nil {
+        return E
+    }
+    E = json.NewEncoder(w).Encode(values)
+    if E != nil {
+        return pushError(E, "JSON_codec::Encode: Encode(values)")
+    }
+    return nil
+}   // End of function JSON_codec::Encode.
+
+/*
+JSON_codec::Decode() reads one JSON array from r, and returns a new list with
+its elements as values. An empty array or null gives an empty list.
+*/
+func (c JSON_codec) Decode(r io.Reader) (*List_base, error) {
+    //------------------------------//
+    //      JSON_codec::Decode      //
+    //------------------------------//
+    dec := json.NewDecoder(r)
+    if c.UseNumber {
+        dec.UseNumber()
+    }
+    var values []interface{}
+    E := dec.Decode(&values)
+    if E != nil {
+        return nil, pushError(E, "JSON_codec::Decode: Decode(&values)")
+    }
+    return listFromValues("JSON_codec::Decode", values)
+}   // End of function JSON_codec::Decode.
+
+/*
+Gob_codec::Encode() writes the values of the list to w as a gob stream.
+*/
+func (c Gob_codec) Encode(w io.Writer, p *List_base) error {
+    //------------------------------//
+    //       Gob_codec::Encode      //
+    //------------------------------//
+    values, E := p.valueSlice("Gob_codec::Encode")
+    if E != nil {
+        return E
+    }
+    E = gob.NewEncoder(w).Encode(values)
+    if E != nil {
+        return pushError(E, "Gob_codec::Encode: Encode(values)")
+    }
+    return nil
+}   // End of function Gob_codec::Encode.
+
+/*
+Gob_codec::Decode() reads a gob stream of values from r, and returns a new list
+with the values.
+*/
+func (c Gob_codec) Decode(r io.Reader) (*List_base, error) {
+    //------------------------------//
+    //       Gob_codec::Decode      //
+    //------------------------------//
+    var values []interface{}
+    E := gob.NewDecoder(r).Decode(&values)
+    if E != nil {
+        return nil, pushError(E, "Gob_codec::Decode: Decode(&values)")
+    }
+    return listFromValues("Gob_codec::Decode", values)
+}   // End of function Gob_codec::Decode.
+
+/*
+List_base::valueSlice() is a private member function for internal use in this
+package.
+It returns the values of the list in order, with the integrity checks of
+List_base::All(). The values of an empty list are an empty slice, not nil.
+*/
+func (p *List_base) valueSlice(fn string) ([]interface{}, error) {
+    //------------------------------//
+    //    List_base::valueSlice     //
+    //------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilArgument, fn + ": p == nil")
+    }
+    values := make([]interface{}, 0)
+    for q, E := range p.All() {
+        if E != nil {
+            return nil, pushError(E, fn + ": p.All()")
+        }
+        values = append(values, q.value)
+    }
+    return values, nil
+}   // End of function List_base::valueSlice.
+
+/*
+listFromValues() is a private function for internal use in this package.
+It returns a new list with the values in order.
+*/
+func listFromValues(fn string, values []interface{}) (*List_base, error) {
+    //------------------------------//
+    //        listFromValues        //
+    //------------------------------//
+    p, E := NewFromSlice(values)
+    if E != nil {
+        return nil, pushError(E, fn + ": NewFromSlice(values)")
+    }
+    return p, nil
+}   // End of function listFromValues.
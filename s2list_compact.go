@@ -0,0 +1,442 @@
+// src/go/s2list_compact.go   2026-10-17
+// Compact contiguous binary layout of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Bytes_codec::EncodeValue
+Bytes_codec::DecodeValue
+Bytes_codec::CodecID
+String_codec::EncodeValue
+String_codec::DecodeValue
+String_codec::CodecID
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+CompactCaps
+List_base::ExportCompact
+List_base::ExportCompactFor
+List_base::exportCompact
+List_base::ImportCompact
+appendCompactMeta
+getCompactMeta
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/binary"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Value_codec converts single list values to and from bytes. It is used by the
+binary representations of lists, like List_base::ExportCompact().
+*/
+type Value_codec interface {
+    EncodeValue(v interface{}) ([]byte, error)
+    DecodeValue(b []byte) (interface{}, error)
+}
+
+/*
+Bytes_codec is a Value_codec for lists whose values are []byte slices. The
+slices are stored unchanged.
+*/
+type Bytes_codec struct{}
+
+/*
+String_codec is a Value_codec for lists whose values are strings.
+*/
+type String_codec struct{}
+
+/*
+Bytes_codec::EncodeValue() returns the []byte value v itself.
+*/
+func (Bytes_codec) EncodeValue(v interface{}) ([]byte, error) {
+    //------------------------------//
+    //   Bytes_codec::EncodeValue   //
+    //------------------------------//
+    b, ok := v.([]byte)
+    if !ok {
+        return nil, newError(ErrInvalidArgument, "Bytes_codec::EncodeValue: value is not []byte")
+    }
+    return b, nil
+}   // End of function Bytes_codec::EncodeValue.
+
+/*
+Bytes_codec::DecodeValue() returns a copy of b.
+*/
+func (Bytes_codec) DecodeValue(b []byte) (interface{}, error) {
+    //------------------------------//
+    //   Bytes_codec::DecodeValue   //
+    //------------------------------//
+    v := make([]byte, len(b))
+    copy(v, b)
+    return v, nil
+}   // End of function Bytes_codec::DecodeValue.
+
+/*
+Bytes_codec::CodecID() returns Codec_bytes. See CodecID().
+*/
+func (Bytes_codec) CodecID() uint16 {
+    //--------------------------//
+    //   Bytes_codec::CodecID   //
+    //--------------------------//
+    return Codec_bytes
+}   // End of function Bytes_codec::CodecID.
+
+/*
+String_codec::EncodeValue() returns the bytes of the string value v.
+*/
+func (String_codec) EncodeValue(v interface{}) ([]byte, error) {
+    //------------------------------//
+    //  String_codec::EncodeValue   //
+    //------------------------------//
+    s, ok := v.(string)
+    if !ok {
+        return nil, newError(ErrInvalidArgument, "String_codec::EncodeValue: value is not string")
+    }
+    return []byte(s), nil
+}   // End of function String_codec::EncodeValue.
+
+/*
+String_codec::DecodeValue() returns b as a string.
+*/
+func (String_codec) DecodeValue(b []byte) (interface{}, error) {
+    //------------------------------//
+    //  String_codec::DecodeValue   //
+    //------------------------------//
+    return string(b), nil
+}   // End of function String_codec::DecodeValue.
+
+/*
+String_codec::CodecID() returns Codec_string. See CodecID().
+*/
+func (String_codec) CodecID() uint16 {
+    //--------------------------//
+    //  String_codec::CodecID   //
+    //--------------------------//
+    return Codec_string
+}   // End of function String_codec::CodecID.
+
+/*
+The compact layout is a single contiguous buffer in which nodes refer to each
+other by byte offsets instead of pointers. All integers are little-endian, so
+the layout is the same on every platform. Version 2 starts with a wire header.
+See Wire_caps.
+    offset 0    wire header with magic "S2LC" and version 2
+    offset 16   number of nodes (uint32)
+    offset 20   offset of the first node record (uint32), or 0 for an empty list
+Each node record is:
+    next        offset of the next node record (uint32), or 0 for the last node
+    length      number of bytes of the encoded value (uint32)
+    value       the bytes of the encoded value
+    meta        the node metadata, if the Wire_meta flag is set
+The metadata is a byte which is 1 if the node has metadata and 0 otherwise,
+followed by the insertion time in Unix nanoseconds (int64), the sequence number
+(uint64), the number of attempts (uint32), the length of the origin tag
+(uint32) and the origin tag. The checksum trailer follows the last record.
+Version 1 has no wire header, metadata or trailer:
+    offset 0    magic "S2LC"
+    offset 4    layout version 1 (uint32)
+    offset 8    number of nodes (uint32)
+    offset 12   offset of the first node record (uint32), or 0 for an empty list
+*/
+const (
+    compact_magic     = "S2LC"
+    compact_version   = 2
+    compact_v1_header = 16
+    compact_header    = wire_header + 8
+)
+
+/*
+CompactCaps() returns the capabilities of this build for the compact layout
+with the value codec vc. A reader of compact buffers can send them to the
+writer, which passes them to List_base::ExportCompactFor().
+*/
+func CompactCaps(vc Value_codec) Wire_caps {
+    //----------------------//
+    //      CompactCaps     //
+    //----------------------//
+    return Wire_caps{Version: compact_version, MinVersion: 1, Codec: CodecID(vc),
+        Flags: Wire_meta, Checksums: 1 << Checksum_none | 1 << Checksum_crc32c}
+}   // End of function CompactCaps.
+
+/*
+List_base::ExportCompact() encodes the list in the compact layout, using the
+value codec vc for the node values. The result is suitable for embedding in
+other binary formats or for copying into a shared memory segment.
+The newest layout version is written, with a CRC-32C trailer, and with the node
+metadata if metadata is enabled for the list. Use List_base::ExportCompactFor()
+for readers which may be older.
+*/
+func (p *List_base) ExportCompact(vc Value_codec) ([]byte, error) {
+    //------------------------------//
+    //   List_base::ExportCompact   //
+    //------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::ExportCompact: p == nil")
+    }
+    return p.exportCompact("List_base::ExportCompact", vc, CompactCaps(vc))
+}   // End of function List_base::ExportCompact.
+
+/*
+List_base::ExportCompactFor() encodes the list in the compact layout for a
+reader with the capabilities peer. The layout version, checksum and metadata
+are those which both sides support. See Negotiate().
+*/
+func (p *List_base) ExportCompactFor(vc Value_codec, peer Wire_caps) ([]byte, error) {
+    //----------------------------------//
+    //   List_base::ExportCompactFor    //
+    //----------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::ExportCompactFor: p == nil")
+    }
+    caps, E := Negotiate(CompactCaps(vc), peer)
+    if E != nil {
+        return nil, pushError(E, "List_base::ExportCompactFor: Negotiate()")
+    }
+    return p.exportCompact("List_base::ExportCompactFor", vc, caps)
+}   // End of function List_base::ExportCompactFor.
+
+/*
+List_base::exportCompact() is a private member function for internal use in
+this package.
+It encodes the list in the compact layout with the negotiated capabilities
+caps. The argument fn is the name of the calling method, for error messages.
+*/
+func (p *List_base) exportCompact(fn string, vc Value_codec, caps Wire_caps) ([]byte, error) {
+    //------------------------------//
+    //   List_base::exportCompact   //
+    //------------------------------//
+    if vc == nil {
+        return nil, p.fail(ErrNilArgument, nil, fn + ": vc == nil")
+    }
+    le := binary.LittleEndian
+    var buf []byte
+    var n_at, first_at int
+    var h wire_hdr
+    if caps.Version < 2 {
+        buf = make([]byte, compact_v1_header)
+        copy(buf, compact_magic)
+        le.PutUint32(buf[4:], 1)
+        n_at, first_at = 8, 12
+    } else {
+        h = wire_hdr{version: compact_version, min_version: 2, codec: CodecID(vc)}
+        if caps.Flags & Wire_meta != 0 && p.meta_on {
+            h.flags |= Wire_meta
+        }
+        if caps.Checksums & (1 << Checksum_crc32c) != 0 {
+            h.checksum = Checksum_crc32c
+        }
+        buf = make([]byte, compact_header)
+        putWireHeader(buf, compact_magic, &h)
+        n_at, first_at = wire_header, wire_header + 4
+    }
+    var n uint32 = 0
+    var prev int = 0 // Offset of the previous record.
+    var it List_iter
+    it.Init(p)
+    for {
+        q, E := it.Next()
+        if E != nil {
+            return nil, pushError(E, fn + ": it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        b, E := vc.EncodeValue(q.value)
+        if E != nil {
+            return nil, pushError(E, fn + ": vc.EncodeValue()")
+        }
+        off := len(buf)
+        if uint64(off)+8+uint64(len(b)) > 0xffffffff {
+            return nil, p.fail(ErrInvalidArgument, nil, fn + ": layout exceeds 4GB")
+        }
+        if prev == 0 {
+            le.PutUint32(buf[first_at:], uint32(off))
+        } else {
+            le.PutUint32(buf[prev:], uint32(off))
+        }
+        var rec [8]byte
+        le.PutUint32(rec[4:], uint32(len(b)))
+        buf = append(buf, rec[:]...)
+        buf = append(buf, b...)
+        if h.flags & Wire_meta != 0 {
+            buf = appendCompactMeta(buf, q.meta)
+        }
+        prev = off
+        n += 1
+    }
+    le.PutUint32(buf[n_at:], n)
+    buf = append(buf, wireChecksum(h.checksum, buf)...)
+    if uint64(len(buf)) > 0xffffffff {
+        return nil, p.fail(ErrInvalidArgument, nil, fn + ": layout exceeds 4GB")
+    }
+    return buf, nil
+}   // End of function List_base::exportCompact.
+
+/*
+List_base::ImportCompact() decodes a buffer in the compact layout and appends
+one node to the list for each node record, using the value codec vc. The number
+of appended nodes is returned. The buffer is checked for consistency, and the
+values are checked against the element type and the validator of the list,
+before any node is appended, so an error leaves the list unchanged. If the list
+has a length limit, the import evicts nodes like other appends.
+Both layout versions are accepted. Values which were encoded with a different
+codec, and buffers which need a newer reader, are rejected. If the buffer
+contains node metadata, it is restored in the appended nodes.
+*/
+func (p *List_base) ImportCompact(data []byte, vc Value_codec) (int, error) {
+    //------------------------------//
+    //   List_base::ImportCompact   //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::ImportCompact: p == nil")
+    }
+    if vc == nil {
+        return 0, p.fail(ErrNilArgument, nil, "List_base::ImportCompact: vc == nil")
+    }
+    le := binary.LittleEndian
+    if len(data) < compact_v1_header || string(data[:4]) != compact_magic {
+        return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: bad magic")
+    }
+    var h wire_hdr
+    var hdr_len, n_at, first_at int
+    if le.Uint32(data[4:]) == 1 {
+        hdr_len, n_at, first_at = compact_v1_header, 8, 12
+    } else {
+        hp, E := getWireHeader("List_base::ImportCompact", data, compact_magic, compact_version)
+        if E != nil {
+            return 0, pushError(E, "List_base::ImportCompact: getWireHeader()")
+        }
+        h = *hp
+        if h.flags & ^Wire_meta != 0 {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: unknown flags")
+        }
+        if h.checksum != Checksum_none && h.checksum != Checksum_crc32c {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: unknown checksum")
+        }
+        id := CodecID(vc)
+        if h.codec != Codec_custom && id != Codec_custom && h.codec != id {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: codec mismatch")
+        }
+        hdr_len, n_at, first_at = compact_header, wire_header, wire_header + 4
+        if len(data) < hdr_len {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: short header")
+        }
+        sum := len(wireChecksum(h.checksum, nil))
+        if len(data) < hdr_len + sum ||
+            string(wireChecksum(h.checksum, data[:len(data)-sum])) != string(data[len(data)-sum:]) {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: checksum mismatch")
+        }
+        data = data[:len(data)-sum]
+    }
+    n := le.Uint32(data[n_at:])
+    var values []interface{}
+    var metas []*Node_meta
+    off := le.Uint32(data[first_at:])
+    for off != 0 {
+        // Records must move forward, so a corrupt buffer can't cause a loop.
+        if uint64(len(values)) >= uint64(n) {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: too many records")
+        }
+        if uint64(off)+8 > uint64(len(data)) || off < uint32(hdr_len) {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: bad record offset")
+        }
+        next := le.Uint32(data[off:])
+        length := le.Uint32(data[off+4:])
+        end := uint64(off) + 8 + uint64(length)
+        if end > uint64(len(data)) {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: bad record length")
+        }
+        v, E := vc.DecodeValue(data[off+8 : end])
+        if E != nil {
+            return 0, pushError(E, "List_base::ImportCompact: vc.DecodeValue()")
+        }
+        if h.flags & Wire_meta != 0 {
+            m, k, E := getCompactMeta(data[end:])
+            if E != nil {
+                return 0, pushError(E, "List_base::ImportCompact: getCompactMeta()")
+            }
+            metas = append(metas, m)
+            end += uint64(k)
+        }
+        if next != 0 && uint64(next) < end {
+            return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: bad next offset")
+        }
+        values = append(values, v)
+        off = next
+    }
+    if uint64(len(values)) != uint64(n) {
+        return 0, p.fail(ErrBadFormat, nil, "List_base::ImportCompact: wrong number of records")
+    }
+    E := p.writable("List_base::ImportCompact")
+    if E != nil {
+        return 0, E
+    }
+    if p.elem_type != nil || p.validator != nil {
+        for _, v := range values {
+            E = p.checkValue("List_base::ImportCompact", nil, v)
+            if E != nil {
+                return 0, E
+            }
+        }
+    }
+    for i, v := range values {
+        E := p.AppendValue(v)
+        if E != nil {
+            return i, pushError(E, "List_base::ImportCompact: p.AppendValue(v)")
+        }
+        if metas != nil && metas[i] != nil {
+            p.last.meta = metas[i]
+        }
+    }
+    return len(values), nil
+}   // End of function List_base::ImportCompact.
+
+/*
+appendCompactMeta() is a private function for internal use in this package.
+It appends the encoding of the node metadata m, which may be nil, to buf.
+*/
+func appendCompactMeta(buf []byte, m *Node_meta) []byte {
+    //--------------------------//
+    //     appendCompactMeta    //
+    //--------------------------//
+    if m == nil {
+        return append(buf, 0)
+    }
+    le := binary.LittleEndian
+    buf = append(buf, 1)
+    buf = le.AppendUint64(buf, uint64(m.Inserted.UnixNano()))
+    buf = le.AppendUint64(buf, m.Seq)
+    buf = le.AppendUint32(buf, uint32(m.Attempts))
+    buf = le.AppendUint32(buf, uint32(len(m.Origin)))
+    return append(buf, m.Origin...)
+}   // End of function appendCompactMeta.
+
+/*
+getCompactMeta() is a private function for internal use in this package.
+It decodes the node metadata at the start of b. It returns the metadata, which
+is nil if the node had none, and the number of bytes of the encoding.
+*/
+func getCompactMeta(b []byte) (*Node_meta, int, error) {
+    //--------------------------//
+    //      getCompactMeta      //
+    //--------------------------//
+    if len(b) < 1 {
+        return nil, 0, newError(ErrBadFormat, "getCompactMeta: short metadata")
+    }
+    if b[0] == 0 {
+        return nil, 1, nil
+    }
+    if b[0] != 1 || len(b) < 25 {
+        return nil, 0, newError(ErrBadFormat, "getCompactMeta: bad metadata")
+    }
+    le := binary.LittleEndian
+    length := le.Uint32(b[21:])
+    if uint64(len(b)) < 25 + uint64(length) {
+        return nil, 0, newError(ErrBadFormat, "getCompactMeta: short origin tag")
+    }
+    m := &Node_meta{Inserted: time.Unix(0, int64(le.Uint64(b[1:]))), Seq: le.Uint64(b[9:]),
+        Attempts: int(le.Uint32(b[17:])), Origin: string(b[25 : 25+length])}
+    return m, 25 + int(length), nil
+}   // End of function getCompactMeta.
@@ -0,0 +1,121 @@
+// src/go/s2list_compare.go   2026-10-17
+// Equality and lexicographic comparison of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Equal
+Compare
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+Equal() returns true if the two lists have the same length and the values at
+each position are equal according to eq. If eq is nil, each pair is compared
+with the Equal function which RegisterComparator() registered for the type of
+the value from a, or with the "==" operator if the type has none. A pair which
+"==" cannot compare, like two []byte values or two maps, is reported with
+ErrInvalidArgument. Two nil lists are equal, and a nil list is equal to an
+empty list.
+*/
+func Equal(a, b *List_base, eq func(x, y interface{}) bool) (bool, error) {
+    //----------------------//
+    //         Equal        //
+    //----------------------//
+    if a == b {
+        return true, nil
+    }
+    if a == nil {
+        a = new(List_base)
+    }
+    if b == nil {
+        b = new(List_base)
+    }
+    var ia, ib List_iter
+    ia.Init(a)
+    ib.Init(b)
+    for {
+        qa, E := ia.Next()
+        if E != nil {
+            return false, pushError(E, "Equal: ia.Next()")
+        }
+        qb, E := ib.Next()
+        if E != nil {
+            return false, pushError(E, "Equal: ib.Next()")
+        }
+        if qa == nil || qb == nil {
+            return qa == qb, nil
+        }
+        if eq == nil {
+            same, ok := tryEqual(qa.value, qb.value)
+            if !ok {
+                return false, a.fail(ErrInvalidArgument, qa, "Equal: values are not comparable")
+            }
+            if !same {
+                return false, nil
+            }
+        } else if !eq(qa.value, qb.value) {
+            return false, nil
+        }
+    }
+}   // End of function Equal.
+
+/*
+Compare() compares the values of the two lists lexicographically, using the
+strict ordering less. The return value is -1 if a is less than b, +1 if a is
+greater than b, and 0 if neither is less than the other. A list which is a
+proper prefix of the other list is the lesser list. A nil list is treated as an
+empty list. If less is nil, the Less function which was registered for the
+type of the first value of a orders all values, and an error is returned if
+that type has none.
+*/
+func Compare(a, b *List_base, less func(x, y interface{}) bool) (int, error) {
+    //----------------------//
+    //        Compare       //
+    //----------------------//
+    if a == b {
+        return 0, nil
+    }
+    if a == nil {
+        a = new(List_base)
+    }
+    if b == nil {
+        b = new(List_base)
+    }
+    var ia, ib List_iter
+    ia.Init(a)
+    ib.Init(b)
+    for {
+        qa, E := ia.Next()
+        if E != nil {
+            return 0, pushError(E, "Compare: ia.Next()")
+        }
+        qb, E := ib.Next()
+        if E != nil {
+            return 0, pushError(E, "Compare: ib.Next()")
+        }
+        switch {
+        case qa == nil && qb == nil:
+            return 0, nil
+        case qa == nil:
+            return -1, nil
+        case qb == nil:
+            return 1, nil
+        }
+        if less == nil {
+            less, E = resolveLess("Compare", nil, qa.value)
+            if E != nil {
+                return 0, E
+            }
+        }
+        switch {
+        case less(qa.value, qb.value):
+            return -1, nil
+        case less(qb.value, qa.value):
+            return 1, nil
+        }
+    }
+}   // End of function Compare.
@@ -0,0 +1,59 @@
+// src/go/s2list_container.go   2026-10-17
+// Conversion between s2list lists and container/list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+FromContainerList
+List_base::ToContainerList
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "container/list"
+
+//=============================================================================
+//=============================================================================
+
+/*
+FromContainerList() returns a new list with the values of the elements of the
+container/list list l, in order. The list l is not modified. A nil l gives an
+empty list.
+*/
+func FromContainerList(l *list.List) *List_base {
+    //------------------------------//
+    //      FromContainerList       //
+    //------------------------------//
+    p := new(List_base)
+    if l == nil {
+        return p
+    }
+    for e := l.Front(); e != nil; e = e.Next() {
+        p.AppendValue(e.Value)
+    }
+    return p
+}   // End of function FromContainerList.
+
+/*
+List_base::ToContainerList() returns a new container/list list with the values
+of the list, in order. The list is not modified. The traversal has the
+integrity checks of List_base::All(). Since there is no error result, the
+conversion of a corrupt list stops at the first defect, so a list which may be
+corrupt should be checked with List_base::Validate() first.
+*/
+func (p *List_base) ToContainerList() *list.List {
+    //----------------------------------//
+    //    List_base::ToContainerList    //
+    //----------------------------------//
+    if p == nil {
+        nilReceiver("List_base::ToContainerList")
+        return nil
+    }
+    l := list.New()
+    for q, E := range p.All() {
+        if E != nil {
+            break
+        }
+        l.PushBack(q.value)
+    }
+    return l
+}   // End of function List_base::ToContainerList.
@@ -0,0 +1,150 @@
+// src/go/s2list_corruption.go   2026-10-17
+// Policies for handling corruption which is detected during list operations.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SetCorruptionPolicy
+List_base::report
+List_base::repairLast
+List_iter::recover
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "log"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Corruption_policy selects what a list does when corruption is detected in the
+middle of an operation.
+    Corruption_fail     return an error (the default)
+    Corruption_skip     log the defect and skip nodes with wrong base-pointers
+    Corruption_repair   log the defect and repair the list where possible
+Under Corruption_skip, List_iter::Next() steps over nodes which do not belong to
+the list. Defects of the list-base itself, like a nil last-pointer, still cause
+errors.
+Under Corruption_repair, a nil last-pointer, or a last-pointer which is not
+reachable from the first node, is re-derived from the chain. A node with a nil
+base-pointer is adopted by the list. At a node which belongs to a different
+list, the chain is cut, so that the list does not continue into the other list.
+Long-running services may prefer these policies to favour availability over
+strictness. Every skipped or repaired defect is logged.
+*/
+type Corruption_policy int
+
+const (
+    Corruption_fail Corruption_policy = iota
+    Corruption_skip
+    Corruption_repair
+)
+
+/*
+List_base::SetCorruptionPolicy() sets the corruption policy of the list. Defects
+are reported to logf, or to the standard logger if logf is nil.
+*/
+func (p *List_base) SetCorruptionPolicy(policy Corruption_policy, logf func(string)) error {
+    //----------------------------------//
+    //  List_base::SetCorruptionPolicy  //
+    //----------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetCorruptionPolicy: p == nil")
+    }
+    if policy < Corruption_fail || policy > Corruption_repair {
+        return p.fail(ErrInvalidArgument, nil, "List_base::SetCorruptionPolicy: unknown policy")
+    }
+    p.corruption = policy
+    p.logf = logf
+    return nil
+}   // End of function List_base::SetCorruptionPolicy.
+
+/*
+List_base::report() logs a skipped or repaired defect.
+*/
+func (p *List_base) report(msg string) {
+    //----------------------//
+    //   List_base::report  //
+    //----------------------//
+    if p.name != "" {
+        msg = p.name + ": " + msg
+    }
+    if p.logf != nil {
+        p.logf(msg)
+    } else {
+        log.Print("s2list: " + msg)
+    }
+}   // End of function List_base::report.
+
+/*
+List_base::repairLast() is called when the last-pointer of a non-empty list is
+nil or not reachable from the first node. Under Corruption_repair, the defect is
+logged, the last-pointer is re-derived from the chain, and nil is returned.
+Otherwise an error with the given message is returned. A cyclic chain has no
+last node, so it cannot be repaired, and an error is returned for it too.
+*/
+func (p *List_base) repairLast(msg string) error {
+    //--------------------------//
+    //  List_base::repairLast   //
+    //--------------------------//
+    if p.corruption != Corruption_repair {
+        return p.corrupt(ErrCorruptList, nil, msg)
+    }
+    // Walk a second pointer at half speed. It is met on a cycle.
+    q, slow := p.first, p.first
+    for i := 0; q.next != nil; i += 1 {
+        q = q.next
+        if i % 2 == 1 {
+            slow = slow.next
+        }
+        if q == slow {
+            return p.corrupt(ErrCorruptList, nil, msg + ": chain is cyclic")
+        }
+    }
+    p.report(msg + ": last-pointer repaired")
+    p.last = q
+    return nil
+}   // End of function List_base::repairLast.
+
+/*
+List_iter::recover() is called by List_iter::Next() under the Corruption_skip
+and Corruption_repair policies, after the current-pointer has been advanced to
+the candidate node, and before the node is delivered. If the candidate does not
+belong to the list, the policy is applied. Afterwards, the current-pointer is
+either nil or points to a node of the list.
+*/
+func (p *List_iter) recover() error {
+    //----------------------//
+    //  List_iter::recover  //
+    //----------------------//
+    b := p.base
+    for p.current != nil && p.current.base != b {
+        q := p.current
+        if p.base.corruption == Corruption_skip {
+            p.base.report("List_iter::Next: skipped node with wrong base-pointer")
+            p.current = q.next
+            continue
+        }
+        if q.base == nil {
+            p.base.report("List_iter::Next: adopted node with nil base-pointer")
+            q.base = b
+            continue
+        }
+        // The chain continues into a different list. Cut it.
+        p.base.report("List_iter::Next: list cut at node of another list")
+        E := p.base.modify("List_iter::Next")
+        if E != nil {
+            return E
+        }
+        if p.prev == nil {
+            p.base.first = nil
+            p.base.last = nil
+        } else {
+            p.prev.next = nil
+            p.base.last = p.prev
+        }
+        p.modcount = p.base.modcount
+        p.current = nil
+    }
+    return nil
+}   // End of function List_iter::recover.
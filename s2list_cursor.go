@@ -0,0 +1,172 @@
+// src/go/s2list_cursor.go   2026-10-17
+// Stable cursors into s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::NewCursor
+List_base::leaving
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+Cursor::
+Cursor::Node
+Cursor::Valid
+Cursor::Next
+Cursor::Reset
+Cursor::Close
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Cursor is a long-lived position in a List_base. Unlike a List_iter, a Cursor
+is registered with its list, and the list keeps the cursor valid while the list
+is modified.
+    base *List_base // The list which the cursor is registered with.
+    node *List_node // The node at the cursor position, or nil.
+When the node at the cursor position is removed from the list, the cursor is
+advanced to the node which followed it. If there is no such node, the cursor
+becomes invalid. Removals of other nodes do not affect the cursor.
+A cursor should be closed with Cursor::Close() when it is no longer needed,
+because every removal from a list costs O(1) for each registered cursor.
+*/
+type Cursor struct {
+    //----------------------//
+    //        Cursor::      //
+    //----------------------//
+    base *List_base // The list which the cursor is registered with.
+    node *List_node // The node at the cursor position, or nil.
+}
+
+/*
+List_base::NewCursor() creates a cursor which is registered with the list and
+positioned at the first node. If the list is empty, the cursor is invalid.
+*/
+func (p *List_base) NewCursor() (*Cursor, error) {
+    //--------------------------//
+    //   List_base::NewCursor   //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::NewCursor: p == nil")
+    }
+    c := new(Cursor)
+    c.base = p
+    c.node = p.first
+    if p.cursors == nil {
+        p.cursors = make(map[*Cursor]bool)
+    }
+    p.cursors[c] = true
+    return c, nil
+}   // End of function List_base::NewCursor.
+
+/*
+List_base::leaving() is a private member function for internal use in this
+package.
+It is called by List_node::unlink() just before the node q is unlinked from
+this list, while q.next still points to its successor. Cursors positioned at q
+are advanced to the successor.
+*/
+func (p *List_base) leaving(q *List_node) {
+    //----------------------//
+    //  List_base::leaving  //
+    //----------------------//
+    for c := range p.cursors {
+        if c.node == q {
+            c.node = q.next
+        }
+    }
+}   // End of function List_base::leaving.
+
+/*
+Cursor::Node() returns the node at the cursor position, or nil if the cursor is
+invalid.
+*/
+func (p *Cursor) Node() *List_node {
+    //----------------------//
+    //     Cursor::Node     //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Cursor::Node")
+        return nil
+    }
+    return p.node
+}   // End of function Cursor::Node.
+
+/*
+Cursor::Valid() returns true if the cursor is positioned at a node.
+*/
+func (p *Cursor) Valid() bool {
+    //----------------------//
+    //     Cursor::Valid    //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Cursor::Valid")
+        return false
+    }
+    return p.node != nil
+}   // End of function Cursor::Valid.
+
+/*
+Cursor::Next() advances the cursor to the following node and returns it. At the
+end of the list, the cursor becomes invalid and the return value is nil.
+An invalid cursor stays invalid until Cursor::Reset() is called.
+*/
+func (p *Cursor) Next() (*List_node, error) {
+    //----------------------//
+    //     Cursor::Next     //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "Cursor::Next: p == nil")
+    }
+    if p.base == nil {
+        return nil, p.base.fail(ErrClosed, nil, "Cursor::Next: cursor is closed")
+    }
+    if p.node == nil {
+        return nil, nil
+    }
+    // Should never happen, since the list advances its cursors.
+    if p.node.base != p.base {
+        q := p.node
+        p.node = nil
+        return nil, p.base.fail(ErrCorruptList, q, "Cursor::Next: p.node.base != p.base")
+    }
+    p.node = p.node.next
+    return p.node, nil
+}   // End of function Cursor::Next.
+
+/*
+Cursor::Reset() positions the cursor at the first node of the list.
+*/
+func (p *Cursor) Reset() error {
+    //----------------------//
+    //     Cursor::Reset    //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Cursor::Reset: p == nil")
+    }
+    if p.base == nil {
+        return p.base.fail(ErrClosed, nil, "Cursor::Reset: cursor is closed")
+    }
+    p.node = p.base.first
+    return nil
+}   // End of function Cursor::Reset.
+
+/*
+Cursor::Close() unregisters the cursor from its list. The cursor is invalid
+afterwards.
+*/
+func (p *Cursor) Close() error {
+    //----------------------//
+    //     Cursor::Close    //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Cursor::Close: p == nil")
+    }
+    if p.base != nil {
+        delete(p.base.cursors, p)
+    }
+    p.base = nil
+    p.node = nil
+    return nil
+}   // End of function Cursor::Close.
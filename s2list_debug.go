@@ -0,0 +1,137 @@
+// src/go/s2list_debug.go   2026-10-17
+// Debug mode with invariant assertions for s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SetDebug
+List_base::debugging
+List_base::debugCheck
+List_base::corrupt
+callSite
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "errors"
+import "fmt"
+import "reflect"
+import "runtime"
+import "strings"
+
+//=============================================================================
+//=============================================================================
+
+/*
+pkg_prefix is the prefix of the fully qualified names of the functions in this
+package, as reported by the runtime.
+*/
+var pkg_prefix = reflect.TypeOf(List_base{}).PkgPath() + "."
+
+/*
+ErrUseAfterRemove is returned by List_node::GetNext() for a node which was
+removed from a list in debug mode. Without debug mode, such a node looks like
+the last node of a list, so a traversal which continues through a popped node
+ends silently.
+*/
+var ErrUseAfterRemove = errors.New("s2list: node used after removal from its list")
+
+/*
+poison_node is the next-pointer of nodes which were removed from a list in debug
+mode. It is never a member of a list.
+*/
+var poison_node = new(List_node)
+
+/*
+List_base::SetDebug() switches the debug mode of the list on or off. In debug
+mode, every mutating method first verifies the full structural invariant of the
+list with List_base::Validate(), and then records its call site, which is the
+first caller outside this package. If the invariant is violated, the error
+names the defect and the call site of the mutation before, which is the most
+likely culprit. Corruption errors of other methods also name the call site of
+the last mutation. Nodes which are removed from the list get a poisoned
+next-pointer, so that List_node::GetNext() returns ErrUseAfterRemove for them.
+Debug mode costs O(n) per mutation. It is switched on for all lists if the
+program is built with the "s2list_debug" build tag.
+*/
+func (p *List_base) SetDebug(on bool) error {
+    //----------------------//
+    //  List_base::SetDebug //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetDebug: p == nil")
+    }
+    p.debug = on
+    return nil
+}   // End of function List_base::SetDebug.
+
+/*
+List_base::debugging() is a private member function for internal use in this
+package.
+It returns true if debug mode is on for the list.
+*/
+func (p *List_base) debugging() bool {
+    //--------------------------//
+    //   List_base::debugging   //
+    //--------------------------//
+    return p.debug || debug_build
+}   // End of function List_base::debugging.
+
+/*
+List_base::debugCheck() is a private member function for internal use in this
+package.
+It is called by List_base::modify() in debug mode. It verifies the invariant of
+the list and records the call site of the mutation which is about to be made.
+*/
+func (p *List_base) debugCheck(fn string) error {
+    //--------------------------//
+    //  List_base::debugCheck   //
+    //--------------------------//
+    E := p.fullCheck(fn)
+    if E != nil {
+        return E
+    }
+    p.last_site = fn + " called from " + callSite()
+    return nil
+}   // End of function List_base::debugCheck.
+
+/*
+List_base::corrupt() is a private member function for internal use in this
+package.
+It returns an error of the given kind for a detected defect of the list, or of
+the node argument q. In debug mode, the message names the last mutation of the list
+and its call site.
+*/
+func (p *List_base) corrupt(kind error, q *List_node, msg string) error {
+    //----------------------//
+    //  List_base::corrupt  //
+    //----------------------//
+    if p.debugging() && p.last_site != "" {
+        msg += " (last mutation: " + p.last_site + ")"
+    }
+    return p.fail(kind, q, msg)
+}   // End of function List_base::corrupt.
+
+/*
+callSite() is a private function for internal use in this package.
+It returns the file and line of the first caller outside this package, or of a
+test file of this package.
+*/
+func callSite() string {
+    //----------------------//
+    //       callSite       //
+    //----------------------//
+    var pcs [32]uintptr
+    n := runtime.Callers(3, pcs[:])
+    frames := runtime.CallersFrames(pcs[:n])
+    for {
+        fr, more := frames.Next()
+        if !strings.HasPrefix(fr.Function, pkg_prefix) ||
+            strings.HasSuffix(fr.File, "_test.go") {
+            return fmt.Sprintf("%s:%d", fr.File, fr.Line)
+        }
+        if !more {
+            break
+        }
+    }
+    return "unknown"
+}   // End of function callSite.
@@ -0,0 +1,9 @@
+// src/go/s2list_debug_off.go   2026-10-17
+// Debug mode only for lists which request it. See s2list_debug_on.go.
+
+//go:build !s2list_debug
+
+package s2list
+
+// debug_build switches on debug mode for all lists. See List_base::SetDebug().
+const debug_build = false
@@ -0,0 +1,9 @@
+// src/go/s2list_debug_on.go   2026-10-17
+// Debug mode for all lists, selected by the "s2list_debug" build tag.
+
+//go:build s2list_debug
+
+package s2list
+
+// debug_build switches on debug mode for all lists. See List_base::SetDebug().
+const debug_build = true
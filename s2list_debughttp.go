@@ -0,0 +1,234 @@
+// src/go/s2list_debughttp.go   2026-10-17
+// HTTP endpoints for the inspection of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::DebugHandler
+RegisterDebugList
+UnregisterDebugList
+DebugListsHandler
+debug_handler::ServeHTTP
+debug_lists::ServeHTTP
+List_base::debugReport
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/json"
+import "fmt"
+import "net/http"
+import "sort"
+import "strconv"
+import "strings"
+import "sync"
+
+//=============================================================================
+//=============================================================================
+
+/*
+debug_limit is the default maximum number of values in a debug report. It can
+be changed per request with the "limit" query parameter.
+*/
+const debug_limit = 100
+
+/*
+A Debug_report is the JSON document which the debug handlers serve for a list.
+    Name       string            // The name of the list. See SetName().
+    Length     int               // The total of List_base::ValidLength().
+    NilBases   int               // Nodes with nil base-pointers.
+    WrongBases int               // Nodes with wrong base-pointers.
+    ModCount   uint64            // See List_base::ModCount().
+    Frozen     bool              // See List_base::Freeze().
+    Values     []json.RawMessage // The first values of the list.
+    Truncated  bool              // True if there are more values.
+Values which cannot be encoded as JSON, such as functions, are reported as
+JSON strings in the %v format of package fmt.
+*/
+type Debug_report struct {
+    Name       string            `json:"name"`        // The name of the list.
+    Length     int               `json:"length"`      // The number of nodes.
+    NilBases   int               `json:"nil_bases"`   // Nil base-pointers.
+    WrongBases int               `json:"wrong_bases"` // Wrong base-pointers.
+    ModCount   uint64            `json:"modcount"`    // See ModCount().
+    Frozen     bool              `json:"frozen"`      // See Freeze().
+    Values     []json.RawMessage `json:"values"`      // The first values.
+    Truncated  bool              `json:"truncated"`   // More values exist.
+}
+
+/*
+A debug_handler serves the report of one list.
+    list *List_base  // The list.
+    mu   sync.Locker // The lock of the list, or nil.
+*/
+type debug_handler struct {
+    list *List_base  // The list.
+    mu   sync.Locker // The lock of the list, or nil.
+}
+
+/*
+The lists which are served by DebugListsHandler(), by name.
+*/
+var debug_mu sync.RWMutex
+var debug_lists_reg = make(map[string]*debug_handler)
+
+/*
+A debug_lists serves the registry of debug lists. See DebugListsHandler().
+*/
+type debug_lists struct{}
+
+/*
+List_base::DebugHandler() returns an HTTP handler which serves a JSON report of
+the list, with its length, the counts of List_base::ValidLength() and its first
+values. The query parameter "limit" sets the maximum number of values, which is
+100 by default. Since List_base has no lock, the list must not be modified
+while a request is served. A list which is shared by goroutines should be
+registered with RegisterDebugList() and its lock instead.
+*/
+func (p *List_base) DebugHandler() http.Handler {
+    //------------------------------//
+    //   List_base::DebugHandler    //
+    //------------------------------//
+    return &debug_handler{list: p}
+}   // End of function List_base::DebugHandler.
+
+/*
+RegisterDebugList() registers the list under the name, for DebugListsHandler().
+If mu is not nil, it is held while the report of the list is made, so it should
+be the lock which protects the list. A later registration for the same name
+replaces the earlier one.
+*/
+func RegisterDebugList(name string, p *List_base, mu sync.Locker) error {
+    //------------------------------//
+    //      RegisterDebugList       //
+    //------------------------------//
+    if name == "" || strings.Contains(name, "/") {
+        return newError(ErrInvalidArgument, "RegisterDebugList: bad name " + strconv.Quote(name))
+    }
+    if p == nil {
+        return newError(ErrNilArgument, "RegisterDebugList: p == nil")
+    }
+    debug_mu.Lock()
+    defer debug_mu.Unlock()
+    debug_lists_reg[name] = &debug_handler{list: p, mu: mu}
+    return nil
+}   // End of function RegisterDebugList.
+
+/*
+UnregisterDebugList() removes the list with the name from the registry of
+DebugListsHandler(). Removing an unknown name has no effect.
+*/
+func UnregisterDebugList(name string) {
+    //------------------------------//
+    //     UnregisterDebugList      //
+    //------------------------------//
+    debug_mu.Lock()
+    defer debug_mu.Unlock()
+    delete(debug_lists_reg, name)
+}   // End of function UnregisterDebugList.
+
+/*
+DebugListsHandler() returns an HTTP handler for the lists which are registered
+with RegisterDebugList(). It is meant to be mounted with
+    http.Handle("/debug/s2list/", http.StripPrefix("/debug/s2list",
+        s2list.DebugListsHandler()))
+The path "/" serves a JSON array of the names of the lists, and the path
+"/NAME" serves the report of the list NAME, like List_base::DebugHandler().
+*/
+func DebugListsHandler() http.Handler {
+    //------------------------------//
+    //      DebugListsHandler       //
+    //------------------------------//
+    return debug_lists{}
+}   // End of function DebugListsHandler.
+
+/*
+debug_handler::ServeHTTP() serves the report of the list.
+*/
+func (p *debug_handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    //----------------------------------//
+    //    debug_handler::ServeHTTP      //
+    //----------------------------------//
+    limit := debug_limit
+    if s := r.URL.Query().Get("limit"); s != "" {
+        n, E := strconv.Atoi(s)
+        if E != nil || n < 0 {
+            http.Error(w, "bad limit", http.StatusBadRequest)
+            return
+        }
+        limit = n
+    }
+    if p.mu != nil {
+        p.mu.Lock()
+    }
+    rep := p.list.debugReport(limit)
+    if p.mu != nil {
+        p.mu.Unlock()
+    }
+    w.Header().Set("Content-Type", "application/json")
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    enc.Encode(rep)
+}   // End of function debug_handler::ServeHTTP.
+
+/*
+debug_lists::ServeHTTP() serves the names of the registered lists, or the report
+of one of them.
+*/
+func (debug_lists) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    //----------------------------------//
+    //      debug_lists::ServeHTTP      //
+    //----------------------------------//
+    name := strings.TrimPrefix(r.URL.Path, "/")
+    debug_mu.RLock()
+    if name == "" {
+        names := make([]string, 0, len(debug_lists_reg))
+        for n := range debug_lists_reg {
+            names = append(names, n)
+        }
+        debug_mu.RUnlock()
+        sort.Strings(names)
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(names)
+        return
+    }
+    e, ok := debug_lists_reg[name]
+    debug_mu.RUnlock()
+    if !ok {
+        http.NotFound(w, r)
+        return
+    }
+    e.ServeHTTP(w, r)
+}   // End of function debug_lists::ServeHTTP.
+
+/*
+List_base::debugReport() is a private member function for internal use in this
+package.
+It returns the debug report of the list with at most limit values. The values
+are visited without the integrity checks of List_iter::Next(), like
+List_base::Format().
+*/
+func (p *List_base) debugReport(limit int) *Debug_report {
+    //------------------------------//
+    //   List_base::debugReport     //
+    //------------------------------//
+    rep := &Debug_report{Values: []json.RawMessage{}}
+    if p == nil {
+        return rep
+    }
+    rep.Name = p.name
+    rep.NilBases, rep.WrongBases, rep.Length = p.ValidLength()
+    rep.ModCount = p.modcount
+    rep.Frozen = p.frozen
+    for q := p.first; q != nil && q != poison_node; q = q.next {
+        if len(rep.Values) >= limit {
+            rep.Truncated = true
+            break
+        }
+        b, E := json.Marshal(q.value)
+        if E != nil {
+            b, _ = json.Marshal(fmt.Sprintf("%v", q.value))
+        }
+        rep.Values = append(rep.Values, b)
+    }
+    return rep
+}   // End of function List_base::debugReport.
@@ -0,0 +1,183 @@
+// src/go/s2list_dedup.go   2026-10-17
+// Removal of duplicate values from s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Dedup
+List_base::Unique
+heldBy
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "reflect"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::Dedup() removes every node whose value is a duplicate of the value of
+the preceding node, according to eq, in a single pass. A run of equal values is
+reduced to its first node. The value of each node is compared with the last
+node which was kept, so eq need not be transitive. If eq is nil, a value is
+compared with the Equal function which was registered for the type of the kept
+value, or with the "==" operator if that type has none. Two values of such a
+type which "==" cannot compare, like two []byte values, stop the pass with
+ErrInvalidArgument, and the nodes which were removed before stay removed. The
+removed nodes are cast adrift, and the number of removed nodes is returned.
+*/
+func (p *List_base) Dedup(eq func(a, b interface{}) bool) (int, error) {
+    //----------------------//
+    //   List_base::Dedup   //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::Dedup: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Dedup", time.Now())
+    }
+    if p.first == nil {
+        return 0, nil
+    }
+    if p.last == nil {
+        return 0, p.fail(ErrCorruptList, nil, "List_base::Dedup: p.first != p.last == nil")
+    }
+    var n int = 0
+    prev := p.first
+    for q := prev.next; q != nil; q = prev.next {
+        if q.base != p {
+            return n, p.fail(ErrCorruptList, q, "List_base::Dedup: q.base != p")
+        }
+        var same bool
+        if eq != nil {
+            same = eq(prev.value, q.value)
+        } else {
+            var ok bool
+            same, ok = tryEqual(prev.value, q.value)
+            if !ok {
+                return n, p.fail(ErrInvalidArgument, q, "List_base::Dedup: values are not comparable")
+            }
+        }
+        if !same {
+            prev = q
+            continue
+        }
+        if n == 0 {
+            E := p.modify("List_base::Dedup")
+            if E != nil {
+                return 0, E
+            }
+        }
+        p.removeAfter(prev, q)
+        // A value which the kept node still holds must not be released.
+        if p.release != nil && !sameValue(prev.value, q.value) {
+            p.retire(q, true)
+        }
+        p.reportRemove(q)
+        n += 1
+    }
+    return n, nil
+}   // End of function List_base::Dedup.
+
+/*
+List_base::Unique() removes every node whose key is equal to the key of an
+earlier node in the list, so that only the first node with each key remains.
+The key of each value is computed by the function hash, and it must be
+comparable, since the keys are kept in a set. If hash is nil, a value whose
+type has both a registered Hash and a registered Equal function is keyed by its
+hash, and the values with equal hashes are told apart with Equal. Values of
+other types are their own keys. All keys are computed before any node is
+removed, so the list is not modified if a key is not comparable. The removed
+nodes are cast adrift, and the number of removed nodes is returned.
+*/
+func (p *List_base) Unique(hash func(interface{}) interface{}) (int, error) {
+    //----------------------//
+    //   List_base::Unique  //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::Unique: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Unique", time.Now())
+    }
+    if p.first != nil && p.last == nil {
+        return 0, p.fail(ErrCorruptList, nil, "List_base::Unique: p.first != p.last == nil")
+    }
+    // A key of this type is a registered hash, which may collide.
+    type hashed_key struct {
+        t reflect.Type
+        h uint64
+    }
+    var keys []interface{}
+    for q := p.first; q != nil; q = q.next {
+        if q.base != p {
+            return 0, p.fail(ErrCorruptList, q, "List_base::Unique: q.base != p")
+        }
+        var k interface{} = q.value
+        if hash != nil {
+            k = hash(q.value)
+        } else if c, ok := LookupComparator(q.value); ok && c.Hash != nil && c.Equal != nil {
+            k = hashed_key{reflect.TypeOf(q.value), c.Hash(q.value)}
+        }
+        if k != nil && !hashable(k) {
+            return 0, p.fail(ErrInvalidArgument, nil, "List_base::Unique: key is not comparable")
+        }
+        keys = append(keys, k)
+    }
+    // The values which were kept, by key.
+    seen := make(map[interface{}][]interface{}, len(keys))
+    var n int = 0
+    var prev *List_node = nil
+    q := p.first
+    for i := 0; q != nil; i += 1 {
+        next := q.next
+        kept, dup := seen[keys[i]]
+        if _, ok := keys[i].(hashed_key); ok && dup {
+            dup = false
+            for _, w := range kept {
+                if valuesEqual(w, q.value) {
+                    dup = true
+                    break
+                }
+            }
+        }
+        if !dup {
+            seen[keys[i]] = append(kept, q.value)
+            prev = q
+            q = next
+            continue
+        }
+        if n == 0 {
+            E := p.modify("List_base::Unique")
+            if E != nil {
+                return 0, E
+            }
+        }
+        p.removeAfter(prev, q)
+        // A value which a kept node still holds must not be released.
+        if p.release != nil && !heldBy(kept, q.value) {
+            p.retire(q, true)
+        }
+        p.reportRemove(q)
+        n += 1
+        q = next
+    }
+    return n, nil
+}   // End of function List_base::Unique.
+
+/*
+heldBy() returns true if the value v is identical to one of the values kept.
+See sameValue().
+*/
+func heldBy(kept []interface{}, v interface{}) bool {
+    //----------------------//
+    //        heldBy        //
+    //----------------------//
+    for _, w := range kept {
+        if sameValue(w, v) {
+            return true
+        }
+    }
+    return false
+}   // End of function heldBy.
@@ -0,0 +1,226 @@
+// src/go/s2list_delay.go   2026-10-17
+// Delay queue of values which become due at given times.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+DelayQueue::
+NewDelayQueue
+DelayQueue::Schedule
+DelayQueue::PopDue
+DelayQueue::WaitNext
+DelayQueue::Len
+DelayQueue::popFirst
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "context"
+import "sync"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A DelayQueue holds values which become due at given times, such as the timers
+of a scheduler. The nodes of the queue are kept in order of their due times.
+Values with equal due times are delivered in the order in which they were
+scheduled. A DelayQueue may be used by several goroutines. The zero value is an
+empty queue.
+    mu      sync.Mutex    // Protects the other fields.
+    list    List_base     // The values, as *delay_entry, in order of due time.
+    changed chan struct{} // Closed when the first value changes.
+*/
+type DelayQueue struct {
+    //----------------------//
+    //     DelayQueue::     //
+    //----------------------//
+    mu      sync.Mutex    // Protects the other fields.
+    list    List_base     // The values, as *delay_entry, in order of due time.
+    changed chan struct{} // Closed when the first value changes.
+}
+
+/*
+A delay_entry is the value of a node of a DelayQueue.
+*/
+type delay_entry struct {
+    value interface{} // The value of the caller.
+    at    time.Time   // When the value is due.
+}
+
+/*
+NewDelayQueue() returns an empty delay queue.
+*/
+func NewDelayQueue() *DelayQueue {
+    //----------------------//
+    //     NewDelayQueue    //
+    //----------------------//
+    return &DelayQueue{changed: make(chan struct{})}
+}   // End of function NewDelayQueue.
+
+/*
+DelayQueue::Schedule() adds the value v, which becomes due at the time at.
+This costs O(1) if at is not before the due time of any value in the queue,
+which is the usual case for a fixed delay. Otherwise it costs O(n).
+*/
+func (p *DelayQueue) Schedule(v interface{}, at time.Time) error {
+    //--------------------------//
+    //   DelayQueue::Schedule   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "DelayQueue::Schedule: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.changed == nil {
+        p.changed = make(chan struct{})
+    }
+    e := &delay_entry{value: v, at: at}
+    if p.list.last == nil || !at.Before(p.list.last.value.(*delay_entry).at) {
+        E := p.list.AppendValue(e)
+        if E != nil {
+            return pushError(E, "DelayQueue::Schedule: p.list.AppendValue(e)")
+        }
+        if p.list.first == p.list.last {
+            close(p.changed)
+            p.changed = make(chan struct{})
+        }
+        return nil
+    }
+    // Insert the value after the last value which is not due later.
+    var it List_iter
+    it.Init(&p.list)
+    var prev *List_node
+    for {
+        q := p.list.first
+        if prev != nil {
+            q = prev.next
+        }
+        if q == nil || at.Before(q.value.(*delay_entry).at) {
+            break
+        }
+        var E error
+        prev, E = it.Next()
+        if E != nil {
+            return pushError(E, "DelayQueue::Schedule: it.Next()")
+        }
+    }
+    first := prev == nil
+    E := it.InsertAfterCurrent(e)
+    if E != nil {
+        return pushError(E, "DelayQueue::Schedule: it.InsertAfterCurrent(e)")
+    }
+    if first {
+        close(p.changed)
+        p.changed = make(chan struct{})
+    }
+    return nil
+}   // End of function DelayQueue::Schedule.
+
+/*
+DelayQueue::PopDue() removes all values which are due at the time now, and
+returns them in order of their due times.
+*/
+func (p *DelayQueue) PopDue(now time.Time) ([]interface{}, error) {
+    //--------------------------//
+    //   DelayQueue::PopDue     //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "DelayQueue::PopDue: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    var due []interface{}
+    for p.list.first != nil && !p.list.first.value.(*delay_entry).at.After(now) {
+        e, E := p.popFirst()
+        if E != nil {
+            return due, pushError(E, "DelayQueue::PopDue: p.popFirst()")
+        }
+        due = append(due, e.value)
+    }
+    return due, nil
+}   // End of function DelayQueue::PopDue.
+
+/*
+DelayQueue::WaitNext() waits until the first value of the queue is due, then
+removes it and returns it with its due time. A value which is scheduled before
+the current first value while waiting is taken into account. The error is the
+error of ctx if ctx is done before a value is due.
+*/
+func (p *DelayQueue) WaitNext(ctx context.Context) (interface{}, time.Time, error) {
+    //--------------------------//
+    //   DelayQueue::WaitNext   //
+    //--------------------------//
+    if p == nil {
+        return nil, time.Time{}, newError(ErrNilReceiver, "DelayQueue::WaitNext: p == nil")
+    }
+    for {
+        p.mu.Lock()
+        if p.changed == nil {
+            p.changed = make(chan struct{})
+        }
+        changed := p.changed
+        var timer *time.Timer
+        var due <-chan time.Time
+        if p.list.first != nil {
+            at := p.list.first.value.(*delay_entry).at
+            d := time.Until(at)
+            if d <= 0 {
+                e, E := p.popFirst()
+                p.mu.Unlock()
+                if E != nil {
+                    return nil, time.Time{}, pushError(E, "DelayQueue::WaitNext: p.popFirst()")
+                }
+                return e.value, e.at, nil
+            }
+            timer = time.NewTimer(d)
+            due = timer.C
+        }
+        p.mu.Unlock()
+        select {
+        case <-ctx.Done():
+            if timer != nil {
+                timer.Stop()
+            }
+            return nil, time.Time{}, ctx.Err()
+        case <-changed:
+        case <-due:
+        }
+        if timer != nil {
+            timer.Stop()
+        }
+    }
+}   // End of function DelayQueue::WaitNext.
+
+/*
+DelayQueue::Len() returns the number of values in the queue.
+*/
+func (p *DelayQueue) Len() int {
+    //----------------------//
+    //    DelayQueue::Len   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("DelayQueue::Len")
+        return 0
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.list.Length()
+}   // End of function DelayQueue::Len.
+
+/*
+DelayQueue::popFirst() is a private member function for internal use in this
+package.
+It pops the first entry of the queue. The caller must hold the lock, and the
+queue must not be empty.
+*/
+func (p *DelayQueue) popFirst() (*delay_entry, error) {
+    //--------------------------//
+    //   DelayQueue::popFirst   //
+    //--------------------------//
+    q, E := p.list.Popfirst()
+    if E != nil {
+        return nil, E
+    }
+    return q.value.(*delay_entry), nil
+}   // End of function DelayQueue::popFirst.
@@ -0,0 +1,487 @@
+// src/go/s2list_durable.go   2026-10-17
+// Persistent queues of s2list values with a write-ahead log.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+DurableQueue::
+OpenDurableQueue
+DurableQueue::SetSyncWrites
+DurableQueue::Push
+DurableQueue::Pop
+DurableQueue::Len
+DurableQueue::Compact
+DurableQueue::Run
+DurableQueue::Close
+DurableQueue::replay
+DurableQueue::write
+allZero
+appendWALRecord
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/binary"
+import "hash/crc32"
+import "os"
+import "path/filepath"
+import "sync"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+The log of a DurableQueue starts with the magic bytes wal_magic and the format
+version wal_version. Then each Push and Pop appends one record:
+    op    1 byte   wal_push or wal_pop.
+    size  uvarint  The length of the encoded value. Only for wal_push.
+    value bytes    The value, encoded by the Value_codec. Only for wal_push.
+    crc   4 bytes  CRC-32C of the preceding bytes of the record, little-endian.
+*/
+const wal_magic = "S2DQ"
+const wal_version = 1
+const wal_push = 'P'
+const wal_pop = 'D'
+
+/*
+A DurableQueue is a FIFO queue of values which survives restarts of the
+process. The values are kept in a list, and every push and pop is appended to
+a log file before it takes effect, so that OpenDurableQueue() can rebuild the
+queue by replaying the log. Values are stored with a Value_codec, such as
+String_codec{} or Bytes_codec{}. DurableQueue::Compact() rewrites the log with
+only the values which are still queued, and DurableQueue::Run() does this
+periodically. A DurableQueue may be used by several goroutines.
+    mu      sync.Mutex  // Protects the other fields.
+    list    List_base   // The queued values.
+    path    string      // The path of the log file.
+    vc      Value_codec // The encoding of the values in the log.
+    f       *os.File    // The log file, open for appending.
+    end     int64       // The length of the valid part of the log.
+    records int         // The number of records in the log.
+    sync    bool        // Sync the log after every record.
+    buf     []byte      // Buffer for encoding records.
+*/
+type DurableQueue struct {
+    //----------------------//
+    //    DurableQueue::    //
+    //----------------------//
+    mu      sync.Mutex  // Protects the other fields.
+    list    List_base   // The queued values.
+    path    string      // The path of the log file.
+    vc      Value_codec // The encoding of the values in the log.
+    f       *os.File    // The log file, open for appending.
+    end     int64       // The length of the valid part of the log.
+    records int         // The number of records in the log.
+    sync    bool        // Sync the log after every record.
+    buf     []byte      // Buffer for encoding records.
+}
+
+/*
+OpenDurableQueue() opens the queue whose log is the file at path, and creates
+an empty log if the file does not exist. The values of the log are decoded with
+vc. If the last record of the log is incomplete or damaged, which happens if
+the process crashed while writing it, the log is truncated before it. The push
+or pop of that record had not returned, so it is lost. Zero bytes after the
+last record, which some file systems leave after a crash, are truncated too.
+A damaged record which is followed by more data is not a torn write, so the
+log is left as it is, and ErrCorruptList is returned, since truncating it
+would lose the valid records after the damage. ErrBadFormat is returned if the
+file is not a log of a DurableQueue. By default, the log is synced to
+the disk after every record. See DurableQueue::SetSyncWrites().
+*/
+func OpenDurableQueue(path string, vc Value_codec) (*DurableQueue, error) {
+    //------------------------------//
+    //       OpenDurableQueue       //
+    //------------------------------//
+    if vc == nil {
+        return nil, newError(ErrNilArgument, "OpenDurableQueue: vc == nil")
+    }
+    p := &DurableQueue{path: path, vc: vc, sync: true}
+    f, E := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+    if E != nil {
+        return nil, pushError(E, "OpenDurableQueue: os.OpenFile()")
+    }
+    p.f = f
+    E = p.replay()
+    if E != nil {
+        f.Close()
+        return nil, pushError(E, "OpenDurableQueue: p.replay()")
+    }
+    return p, nil
+}   // End of function OpenDurableQueue.
+
+/*
+DurableQueue::SetSyncWrites() switches the syncing of the log after every
+record on or off. Without syncing, DurableQueue::Push() and DurableQueue::Pop()
+are much faster, but the records of the last moments before a crash of the
+system may be lost. A crash of only the process loses nothing.
+*/
+func (p *DurableQueue) SetSyncWrites(on bool) error {
+    //----------------------------------//
+    //    DurableQueue::SetSyncWrites   //
+    //----------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "DurableQueue::SetSyncWrites: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.sync = on
+    return nil
+}   // End of function DurableQueue::SetSyncWrites.
+
+/*
+DurableQueue::Push() appends the value v to the queue. The value is logged
+before it is queued, so it is in the queue after a restart if Push() returns
+nil.
+*/
+func (p *DurableQueue) Push(v interface{}) error {
+    //--------------------------//
+    //    DurableQueue::Push    //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "DurableQueue::Push: p == nil")
+    }
+    b, E := p.vc.EncodeValue(v)
+    if E != nil {
+        return pushError(E, "DurableQueue::Push: p.vc.EncodeValue(v)")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.f == nil {
+        return p.list.fail(ErrClosed, nil, "DurableQueue::Push: queue is closed")
+    }
+    E = p.write(wal_push, b)
+    if E != nil {
+        return pushError(E, "DurableQueue::Push: p.write()")
+    }
+    E = p.list.AppendValue(v)
+    if E != nil {
+        return pushError(E, "DurableQueue::Push: p.list.AppendValue(v)")
+    }
+    return nil
+}   // End of function DurableQueue::Push.
+
+/*
+DurableQueue::Pop() removes the first value of the queue and returns it with
+true, or returns false if the queue is empty. The removal is logged first, so
+the value is not in the queue after a restart if Pop() returns it.
+*/
+func (p *DurableQueue) Pop() (interface{}, bool, error) {
+    //--------------------------//
+    //     DurableQueue::Pop    //
+    //--------------------------//
+    if p == nil {
+        return nil, false, newError(ErrNilReceiver, "DurableQueue::Pop: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.f == nil {
+        return nil, false, p.list.fail(ErrClosed, nil, "DurableQueue::Pop: queue is closed")
+    }
+    if p.list.first == nil {
+        return nil, false, nil
+    }
+    E := p.write(wal_pop, nil)
+    if E != nil {
+        return nil, false, pushError(E, "DurableQueue::Pop: p.write()")
+    }
+    v, ok, E := p.list.PopfirstValue()
+    if E != nil {
+        return nil, false, pushError(E, "DurableQueue::Pop: p.list.PopfirstValue()")
+    }
+    return v, ok, nil
+}   // End of function DurableQueue::Pop.
+
+/*
+DurableQueue::Len() returns the number of values in the queue.
+*/
+func (p *DurableQueue) Len() int {
+    //--------------------------//
+    //     DurableQueue::Len    //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("DurableQueue::Len")
+        return 0
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.list.Length()
+}   // End of function DurableQueue::Len.
+
+/*
+DurableQueue::Compact() replaces the log with a log which holds only a push
+record for each queued value. Like List_base::SaveFile(), the new log is
+written to a temporary file which is renamed to the path of the log, so a crash
+leaves either the old log or the new one. Compacting a log without pop records
+has no effect.
+*/
+func (p *DurableQueue) Compact() error {
+    //------------------------------//
+    //    DurableQueue::Compact     //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "DurableQueue::Compact: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.f == nil {
+        return p.list.fail(ErrClosed, nil, "DurableQueue::Compact: queue is closed")
+    }
+    n := p.list.Length()
+    if p.records == n {
+        return nil
+    }
+    buf := []byte(wal_magic)
+    buf = append(buf, wal_version)
+    for q, E := range p.list.All() {
+        if E != nil {
+            return pushError(E, "DurableQueue::Compact: p.list.All()")
+        }
+        b, E := p.vc.EncodeValue(q.value)
+        if E != nil {
+            return pushError(E, "DurableQueue::Compact: p.vc.EncodeValue()")
+        }
+        buf = appendWALRecord(buf, wal_push, b)
+    }
+    dir := filepath.Dir(p.path)
+    f, E := os.CreateTemp(dir, "." + filepath.Base(p.path) + ".tmp*")
+    if E != nil {
+        return pushError(E, "DurableQueue::Compact: os.CreateTemp()")
+    }
+    tmp := f.Name()
+    _, E = f.Write(buf)
+    if E == nil {
+        E = f.Sync()
+    }
+    if E == nil {
+        E = os.Rename(tmp, p.path)
+    }
+    if E != nil {
+        f.Close()
+        os.Remove(tmp)
+        return pushError(E, "DurableQueue::Compact: write " + tmp)
+    }
+    // The temporary file is now the log, positioned at its end.
+    p.f.Close()
+    p.f = f
+    p.end = int64(len(buf))
+    p.records = n
+    return syncDir(dir)
+}   // End of function DurableQueue::Compact.
+
+/*
+DurableQueue::Run() calls DurableQueue::Compact() at the given interval until
+stop is closed or an error occurs.
+*/
+func (p *DurableQueue) Run(interval time.Duration, stop <-chan struct{}) error {
+    //--------------------------//
+    //     DurableQueue::Run    //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "DurableQueue::Run: p == nil")
+    }
+    if interval <= 0 {
+        return newError(ErrInvalidArgument, "DurableQueue::Run: interval <= 0")
+    }
+    tick := time.NewTicker(interval)
+    defer tick.Stop()
+    for {
+        select {
+        case <-stop:
+            return nil
+        case <-tick.C:
+        }
+        E := p.Compact()
+        if E != nil {
+            return pushError(E, "DurableQueue::Run: p.Compact()")
+        }
+    }
+}   // End of function DurableQueue::Run.
+
+/*
+DurableQueue::Close() closes the log. Later pushes and pops return ErrClosed.
+The queued values remain in the log for the next OpenDurableQueue(). Closing a
+closed queue has no effect.
+*/
+func (p *DurableQueue) Close() error {
+    //--------------------------//
+    //    DurableQueue::Close   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "DurableQueue::Close: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.f == nil {
+        return nil
+    }
+    E := p.f.Close()
+    p.f = nil
+    if E != nil {
+        return pushError(E, "DurableQueue::Close: p.f.Close()")
+    }
+    return nil
+}   // End of function DurableQueue::Close.
+
+/*
+DurableQueue::replay() is a private member function for internal use in this
+package.
+It rebuilds the list from the log, truncates a damaged last record of the log,
+and positions the log at its end. Damage before the last record is an error. A
+new log gets its header.
+*/
+func (p *DurableQueue) replay() error {
+    //--------------------------//
+    //   DurableQueue::replay   //
+    //--------------------------//
+    data, E := os.ReadFile(p.path)
+    if E != nil {
+        return pushError(E, "DurableQueue::replay: os.ReadFile()")
+    }
+    if len(data) == 0 {
+        hdr := append([]byte(wal_magic), wal_version)
+        _, E = p.f.Write(hdr)
+        if E != nil {
+            return pushError(E, "DurableQueue::replay: p.f.Write(hdr)")
+        }
+        p.end = int64(len(hdr))
+        return p.f.Sync()
+    }
+    if len(data) < len(wal_magic) + 1 || string(data[:len(wal_magic)]) != wal_magic {
+        return newError(ErrBadFormat, "DurableQueue::replay: bad magic")
+    }
+    if data[len(wal_magic)] != wal_version {
+        return newError(ErrBadFormat, "DurableQueue::replay: unknown version")
+    }
+    good := len(wal_magic) + 1
+    for good < len(data) {
+        rec := data[good:]
+        n := 1
+        var value []byte
+        // The record is the tail if it reaches the end of the log.
+        tail := false
+        switch rec[0] {
+        case wal_push:
+            size, k := binary.Uvarint(rec[1:])
+            if k == 0 || k > 0 && size > uint64(len(rec) - 1 - k) {
+                n = -1
+                tail = true
+                break
+            }
+            if k < 0 {
+                n = -1
+                break
+            }
+            n += k + int(size)
+            value = rec[1 + k:n]
+        case wal_pop:
+        default:
+            n = -1
+        }
+        if n >= 0 && n + 4 >= len(rec) {
+            tail = true
+        }
+        if n < 0 || n + 4 > len(rec) ||
+            binary.LittleEndian.Uint32(rec[n:]) != crc32.Checksum(rec[:n], crc32c_table) {
+            // Zero bytes after the record may be left by a crash too.
+            rest := rec
+            if n >= 0 && n + 4 <= len(rec) {
+                rest = rec[n + 4:]
+            }
+            if !tail && !allZero(rest) {
+                return newError(ErrCorruptList, "DurableQueue::replay: damaged record before the end of the log")
+            }
+            // A torn or damaged tail.
+            break
+        }
+        if rec[0] == wal_push {
+            v, E := p.vc.DecodeValue(value)
+            if E != nil {
+                return pushError(E, "DurableQueue::replay: p.vc.DecodeValue()")
+            }
+            E = p.list.AppendValue(v)
+            if E != nil {
+                return pushError(E, "DurableQueue::replay: p.list.AppendValue(v)")
+            }
+        } else if _, ok, _ := p.list.PopfirstValue(); !ok {
+            return newError(ErrBadFormat, "DurableQueue::replay: pop from empty queue")
+        }
+        p.records += 1
+        good += n + 4
+    }
+    if good < len(data) {
+        E = p.f.Truncate(int64(good))
+        if E != nil {
+            return pushError(E, "DurableQueue::replay: p.f.Truncate()")
+        }
+    }
+    _, E = p.f.Seek(int64(good), 0)
+    if E != nil {
+        return pushError(E, "DurableQueue::replay: p.f.Seek()")
+    }
+    p.end = int64(good)
+    return nil
+}   // End of function DurableQueue::replay.
+
+/*
+DurableQueue::write() is a private member function for internal use in this
+package.
+It appends a record to the log, and syncs the log if requested. If the write
+fails, a partial record is cut off, so that later records are not lost behind
+it. The caller must hold the lock.
+*/
+func (p *DurableQueue) write(op byte, value []byte) error {
+    //--------------------------//
+    //    DurableQueue::write   //
+    //--------------------------//
+    p.buf = appendWALRecord(p.buf[:0], op, value)
+    _, E := p.f.Write(p.buf)
+    if E != nil {
+        p.f.Truncate(p.end)
+        p.f.Seek(p.end, 0)
+        return pushError(E, "DurableQueue::write: p.f.Write()")
+    }
+    p.end += int64(len(p.buf))
+    if p.sync {
+        E = p.f.Sync()
+        if E != nil {
+            return pushError(E, "DurableQueue::write: p.f.Sync()")
+        }
+    }
+    p.records += 1
+    return nil
+}   // End of function DurableQueue::write.
+
+/*
+appendWALRecord() is a private function for internal use in this package.
+It appends a log record with the operation op and the encoded value to buf.
+*/
+func appendWALRecord(buf []byte, op byte, value []byte) []byte {
+    //--------------------------//
+    //     appendWALRecord      //
+    //--------------------------//
+    start := len(buf)
+    buf = append(buf, op)
+    if op == wal_push {
+        buf = binary.AppendUvarint(buf, uint64(len(value)))
+        buf = append(buf, value...)
+    }
+    return binary.LittleEndian.AppendUint32(buf,
+        crc32.Checksum(buf[start:], crc32c_table))
+}   // End of function appendWALRecord.
+
+/*
+allZero() is a private function for internal use in this package.
+It returns true if all bytes of b are zero.
+*/
+func allZero(b []byte) bool {
+    //----------------------//
+    //        allZero       //
+    //----------------------//
+    for _, c := range b {
+        if c != 0 {
+            return false
+        }
+    }
+    return true
+}   // End of function allZero.
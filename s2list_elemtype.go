@@ -0,0 +1,136 @@
+// src/go/s2list_elemtype.go   2026-10-17
+// Enforcement of the value type and value rules of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SetElementType
+List_base::ElementType
+List_base::SetValidator
+List_base::checkValue
+List_base::checkChain
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "reflect"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::SetElementType() restricts the values of the list to the type t.
+Afterwards every method which inserts a value into the list returns
+ErrWrongType for a value which is not assignable to t. These are the appends
+and prepends, List_base::Replace(), List_base::RequeueToBack(),
+List_iter::InsertAfterCurrent() and List_node::SetValue(), and the methods
+which move nodes into the list from other lists, such as
+List_base::TransferAllTo(), List_base::AttachAll(), List_base::StealFirstN()
+and SwapContents(). Such a move checks all its values first, and moves nothing
+if one of them is rejected. If t is an interface type, values of all types
+which implement it are accepted, and so is nil. Otherwise nil is rejected.
+Values which are already in the list are not checked. A nil type switches the
+check off.
+This makes the homogeneity of a list enforceable by its owner. See List_node.
+*/
+func (p *List_base) SetElementType(t reflect.Type) error {
+    //------------------------------//
+    //   List_base::SetElementType  //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetElementType: p == nil")
+    }
+    p.elem_type = t
+    return nil
+}   // End of function List_base::SetElementType.
+
+/*
+List_base::ElementType() returns the type which the values of the list are
+restricted to, or nil if there is no restriction.
+*/
+func (p *List_base) ElementType() reflect.Type {
+    //------------------------------//
+    //    List_base::ElementType    //
+    //------------------------------//
+    if p == nil {
+        nilReceiver("List_base::ElementType")
+        return nil
+    }
+    return p.elem_type
+}   // End of function List_base::ElementType.
+
+/*
+List_base::SetValidator() registers a function which checks every value which
+is inserted into the list, by the methods which check the element type. See
+List_base::SetElementType(). If the validator returns an error, the value is
+rejected, and the method returns an error which wraps it, so that errors.Is()
+matches the error of the validator. The element type is checked first.
+This enforces the domain rules of the values, such as non-nil values, ranges
+or schemas, at the boundary of the list. A nil function switches the
+validation off.
+*/
+func (p *List_base) SetValidator(validate func(v interface{}) error) error {
+    //------------------------------//
+    //   List_base::SetValidator    //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetValidator: p == nil")
+    }
+    p.validator = validate
+    return nil
+}   // End of function List_base::SetValidator.
+
+/*
+List_base::checkValue() is a private member function for internal use in this
+package.
+It returns an error if the value v must not be stored in the list. The node q
+which will hold the value is given for the error context. It may be nil.
+*/
+func (p *List_base) checkValue(fn string, q *List_node, v interface{}) error {
+    //--------------------------//
+    //  List_base::checkValue   //
+    //--------------------------//
+    if p.elem_type != nil {
+        if v == nil {
+            if p.elem_type.Kind() != reflect.Interface {
+                return p.fail(ErrWrongType, q, fn + ": nil value, want " +
+                    p.elem_type.String())
+            }
+        } else if t := reflect.TypeOf(v); !t.AssignableTo(p.elem_type) {
+            return p.fail(ErrWrongType, q, fn + ": value of type " + t.String() +
+                ", want " + p.elem_type.String())
+        }
+    }
+    if p.validator != nil {
+        E := p.validator(v)
+        if E != nil {
+            return pushError(E, fn + ": p.validator(v)")
+        }
+    }
+    return nil
+}   // End of function List_base::checkValue.
+
+/*
+List_base::checkChain() is a private member function for internal use in this
+package.
+It checks the values of the chain of nodes from first to last with
+List_base::checkValue(), before the chain is moved into the list. It returns
+the first error.
+*/
+func (p *List_base) checkChain(fn string, first, last *List_node) error {
+    //--------------------------//
+    //  List_base::checkChain   //
+    //--------------------------//
+    if p.elem_type == nil && p.validator == nil {
+        return nil
+    }
+    for q := first; q != nil; q = q.next {
+        E := p.checkValue(fn, q, q.value)
+        if E != nil {
+            return E
+        }
+        if q == last {
+            break
+        }
+    }
+    return nil
+}   // End of function List_base::checkChain.
@@ -0,0 +1,40 @@
+// src/go/s2list_elist_off.go   2026-10-17
+// Standard-library errors only. See s2list_elist_on.go.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+elistNew
+elistPush
+-------------------------------------------------------------------------*/
+
+//go:build s2list_stderrors
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+// elist_build is false if the elist package is excluded from the build.
+const elist_build = false
+
+/*
+elistNew() is a private function for internal use in this package.
+It is never called when elist_build is false.
+*/
+func elistNew(msg string) error {
+    //----------------------//
+    //       elistNew       //
+    //----------------------//
+    return stdNew(msg)
+}   // End of function elistNew.
+
+/*
+elistPush() is a private function for internal use in this package.
+It is never called when elist_build is false.
+*/
+func elistPush(E error, msg string) error {
+    //----------------------//
+    //       elistPush      //
+    //----------------------//
+    return stdPush(E, msg)
+}   // End of function elistPush.
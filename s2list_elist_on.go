@@ -0,0 +1,42 @@
+// src/go/s2list_elist_on.go   2026-10-17
+// Error messages as elist chains. Excluded by the "s2list_stderrors" build tag.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+elistNew
+elistPush
+-------------------------------------------------------------------------*/
+
+//go:build !s2list_stderrors
+
+package s2list
+
+import "github.com/drauk/elist"
+
+//=============================================================================
+//=============================================================================
+
+// elist_build is false if the elist package is excluded from the build.
+const elist_build = true
+
+/*
+elistNew() is a private function for internal use in this package.
+It returns a new elist error with the message msg.
+*/
+func elistNew(msg string) error {
+    //----------------------//
+    //       elistNew       //
+    //----------------------//
+    return elist.New(msg)
+}   // End of function elistNew.
+
+/*
+elistPush() is a private function for internal use in this package.
+It pushes the message msg onto the error E with elist.Push().
+*/
+func elistPush(E error, msg string) error {
+    //----------------------//
+    //       elistPush      //
+    //----------------------//
+    return elist.Push(E, msg)
+}   // End of function elistPush.
@@ -0,0 +1,252 @@
+// src/go/s2list_errors.go   2026-10-17
+// Sentinel errors of the s2list package.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+SetStdErrors
+StdErrors
+newError
+List_base::fail
+pushError
+newMessage
+pushMessage
+stdNew
+stdPush
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+S2Error::Error
+S2Error::Unwrap
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "errors"
+import "fmt"
+import "strings"
+import "sync/atomic"
+
+//=============================================================================
+//=============================================================================
+
+/*
+The sentinel errors classify the errors which are returned by this package.
+Every error which a method returns is an *S2Error which wraps one of them, or
+the error of another package which caused the failure, so that callers can use
+errors.Is() instead of matching messages.
+    ErrNilReceiver      the receiver is nil, or an iterator has no list
+    ErrNilArgument      a required argument is nil
+    ErrInvalidArgument  an argument is out of range or otherwise unusable
+    ErrNodeInOtherList  a node to be inserted is already in a list
+    ErrNotMember        a node argument is not a member of the list
+    ErrCorruptList      a structural defect of the list was detected
+    ErrReadOnly         the list is a snapshot or otherwise read-only
+    ErrFrozen           the list has been frozen by List_base::Freeze()
+    ErrWrongType        a value is not of the element type of the list
+    ErrPinned           the value of a pinned node cannot be changed
+    ErrBadFormat        encoded data cannot be decoded
+    ErrClosed           the object has been closed
+ErrConcurrentModification and ErrUseAfterRemove are also returned unwrapped.
+*/
+var (
+    ErrNilReceiver     = errors.New("s2list: nil receiver")
+    ErrNilArgument     = errors.New("s2list: nil argument")
+    ErrInvalidArgument = errors.New("s2list: invalid argument")
+    ErrNodeInOtherList = errors.New("s2list: node is already in a list")
+    ErrNotMember       = errors.New("s2list: node is not a member of the list")
+    ErrCorruptList     = errors.New("s2list: corrupt list structure")
+    ErrReadOnly        = errors.New("s2list: list is read-only")
+    ErrFrozen          = errors.New("s2list: list is frozen")
+    ErrWrongType       = errors.New("s2list: value of wrong type")
+    ErrPinned          = errors.New("s2list: node is pinned")
+    ErrBadFormat       = errors.New("s2list: bad data format")
+    ErrClosed          = errors.New("s2list: closed")
+)
+
+/*
+An S2Error is the type of the errors which this package returns. It carries the
+context of the failure in fields which can be inspected with errors.As(), for
+example for structured logging.
+    Op        string     // The failing method, e.g. "List_base::Remove".
+    List      *List_base // The list, if known.
+    Node      *List_node // The offending node, if any.
+    Invariant string     // The violated condition, e.g. "q.base != p".
+    Kind      error      // The sentinel error, or the error of another package.
+The message of the error is the elist message chain, or a chain of plain
+wrapped errors. See SetStdErrors(). Both Kind and the message error are visible
+to errors.Is(). The context is that of the innermost failure
+when the error is passed up through several methods.
+*/
+type S2Error struct {
+    Op        string     // The failing method, e.g. "List_base::Remove".
+    List      *List_base // The list, if known.
+    Node      *List_node // The offending node, if any.
+    Invariant string     // The violated condition, e.g. "q.base != p".
+    Kind      error      // The sentinel error, or the error of another package.
+    err       error      // The elist or standard error with the messages.
+}
+
+/*
+std_errors is non-zero if the messages of errors are plain standard-library
+errors instead of elist chains. See SetStdErrors().
+*/
+var std_errors int32 = 0
+
+/*
+SetStdErrors() selects the form of the messages of all errors which are
+returned by this package after the call. If on is true, each message is a plain
+error, and a message which is pushed onto an error wraps it as
+fmt.Errorf("%s: %w") does. Otherwise the messages are elist chains, which is
+the default.
+The kinds and contexts of the errors, and therefore errors.Is() and
+errors.As(), are the same in both forms.
+If the package is built with the "s2list_stderrors" build tag, the elist
+package is not linked, and the messages are always plain errors.
+*/
+func SetStdErrors(on bool) {
+    //----------------------//
+    //     SetStdErrors     //
+    //----------------------//
+    if on {
+        atomic.StoreInt32(&std_errors, 1)
+    } else {
+        atomic.StoreInt32(&std_errors, 0)
+    }
+}   // End of function SetStdErrors.
+
+/*
+StdErrors() returns true if the messages of errors are plain standard-library
+errors instead of elist chains.
+*/
+func StdErrors() bool {
+    //----------------------//
+    //       StdErrors      //
+    //----------------------//
+    return !elist_build || atomic.LoadInt32(&std_errors) != 0
+}   // End of function StdErrors.
+
+/*
+newError() is a private function for internal use in this package.
+It returns a new error of the given kind with the message msg, which has the
+form "Op: condition".
+*/
+func newError(kind error, msg string) error {
+    //----------------------//
+    //       newError       //
+    //----------------------//
+    E := &S2Error{Kind: kind, err: newMessage(msg)}
+    E.Op, E.Invariant, _ = strings.Cut(msg, ": ")
+    return E
+}   // End of function newError.
+
+/*
+List_base::fail() is a private member function for internal use in this
+package.
+It returns a new error like newError(), with the list and the node q as context.
+The receiver may be nil.
+*/
+func (p *List_base) fail(kind error, q *List_node, msg string) error {
+    //----------------------//
+    //    List_base::fail   //
+    //----------------------//
+    E := newError(kind, msg).(*S2Error)
+    E.List = p
+    E.Node = q
+    // Count the integrity errors. See List_base::EnableStats().
+    if p != nil && p.stats != nil && (kind == ErrCorruptList ||
+        kind == ErrNotMember || kind == ErrNodeInOtherList) {
+        atomic.AddUint64(&p.stats.errors, 1)
+    }
+    return E
+}   // End of function List_base::fail.
+
+/*
+pushError() is a private function for internal use in this package.
+It pushes the message msg onto the error E, like elist.Push(), and keeps the
+kind and context of E. An error from another package becomes the kind, and the
+operation is taken from msg.
+*/
+func pushError(E error, msg string) error {
+    //----------------------//
+    //       pushError      //
+    //----------------------//
+    var k *S2Error
+    if errors.As(E, &k) {
+        r := *k
+        r.err = pushMessage(k.err, msg)
+        return &r
+    }
+    r := &S2Error{Kind: E, err: pushMessage(E, msg)}
+    r.Op, _, _ = strings.Cut(msg, ": ")
+    return r
+}   // End of function pushError.
+
+/*
+newMessage() is a private function for internal use in this package.
+It returns a new message error in the form which is selected by SetStdErrors().
+*/
+func newMessage(msg string) error {
+    //----------------------//
+    //      newMessage      //
+    //----------------------//
+    if StdErrors() {
+        return stdNew(msg)
+    }
+    return elistNew(msg)
+}   // End of function newMessage.
+
+/*
+pushMessage() is a private function for internal use in this package.
+It pushes the message msg onto the error E in the form which is selected by
+SetStdErrors().
+*/
+func pushMessage(E error, msg string) error {
+    //----------------------//
+    //      pushMessage     //
+    //----------------------//
+    if StdErrors() {
+        return stdPush(E, msg)
+    }
+    return elistPush(E, msg)
+}   // End of function pushMessage.
+
+/*
+stdNew() is a private function for internal use in this package.
+It returns a plain error with the message msg.
+*/
+func stdNew(msg string) error {
+    //----------------------//
+    //        stdNew        //
+    //----------------------//
+    return errors.New(msg)
+}   // End of function stdNew.
+
+/*
+stdPush() is a private function for internal use in this package.
+It returns an error with the message msg which wraps E, in the usual Go form
+"msg: E".
+*/
+func stdPush(E error, msg string) error {
+    //----------------------//
+    //        stdPush       //
+    //----------------------//
+    return fmt.Errorf("%s: %w", msg, E)
+}   // End of function stdPush.
+
+/*
+S2Error::Error() returns the messages of the error.
+*/
+func (p *S2Error) Error() string {
+    //----------------------//
+    //    S2Error::Error    //
+    //----------------------//
+    return p.err.Error()
+}   // End of function S2Error::Error.
+
+/*
+S2Error::Unwrap() returns the kind and the message error.
+*/
+func (p *S2Error) Unwrap() []error {
+    //----------------------//
+    //    S2Error::Unwrap   //
+    //----------------------//
+    return []error{p.Kind, p.err}
+}   // End of function S2Error::Unwrap.
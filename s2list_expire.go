@@ -0,0 +1,210 @@
+// src/go/s2list_expire.go   2026-10-17
+// Lists of values with deadlines, which expire automatically.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+ExpiringList::
+NewExpiringList
+ExpiringList::Append
+ExpiringList::Remove
+ExpiringList::Len
+ExpiringList::PurgeExpired
+ExpiringList::Run
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "sync"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+An ExpiringList is a list of values which each carry a deadline, such as a
+table of pending requests. Values whose deadlines have passed are removed by
+ExpiringList::PurgeExpired(), or periodically by ExpiringList::Run() in a
+background goroutine, and are handed to the expiry callback.
+An ExpiringList may be used by several goroutines.
+    mu        sync.Mutex                   // Protects the list.
+    list      List_base                    // The values, as *expiring_entry.
+    on_expire func(interface{}, time.Time) // Receives the expired values.
+*/
+type ExpiringList struct {
+    //--------------------------//
+    //      ExpiringList::      //
+    //--------------------------//
+    mu        sync.Mutex                   // Protects the list.
+    list      List_base                    // The values, as *expiring_entry.
+    on_expire func(interface{}, time.Time) // Receives the expired values.
+}
+
+/*
+An expiring_entry is the value of a node of an ExpiringList.
+*/
+type expiring_entry struct {
+    value    interface{} // The value of the caller.
+    deadline time.Time   // When the value expires.
+}
+
+/*
+NewExpiringList() returns an empty expiring list. The function onExpire is
+called with each expired value and its deadline. It may be nil.
+*/
+func NewExpiringList(onExpire func(v interface{}, deadline time.Time)) *ExpiringList {
+    //----------------------//
+    //    NewExpiringList   //
+    //----------------------//
+    return &ExpiringList{on_expire: onExpire}
+}   // End of function NewExpiringList.
+
+/*
+ExpiringList::Append() appends the value v, which expires at the deadline.
+*/
+func (p *ExpiringList) Append(v interface{}, deadline time.Time) error {
+    //--------------------------//
+    //   ExpiringList::Append   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "ExpiringList::Append: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    E := p.list.AppendValue(&expiring_entry{value: v, deadline: deadline})
+    if E != nil {
+        return pushError(E, "ExpiringList::Append: p.list.AppendValue()")
+    }
+    return nil
+}   // End of function ExpiringList::Append.
+
+/*
+ExpiringList::Remove() removes the first value which satisfies eq before it
+expires, and returns it with its deadline. The boolean return value is false
+if there is no such value. The expiry callback is not called.
+*/
+func (p *ExpiringList) Remove(eq func(v interface{}) bool) (interface{}, time.Time, bool, error) {
+    //--------------------------//
+    //   ExpiringList::Remove   //
+    //--------------------------//
+    if p == nil {
+        return nil, time.Time{}, false, newError(ErrNilReceiver, "ExpiringList::Remove: p == nil")
+    }
+    if eq == nil {
+        return nil, time.Time{}, false, newError(ErrNilArgument, "ExpiringList::Remove: eq == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    var it List_iter
+    it.Init(&p.list)
+    for {
+        q, E := it.Next()
+        if E != nil {
+            return nil, time.Time{}, false, pushError(E, "ExpiringList::Remove: it.Next()")
+        }
+        if q == nil {
+            return nil, time.Time{}, false, nil
+        }
+        e := q.value.(*expiring_entry)
+        if !eq(e.value) {
+            continue
+        }
+        _, E = it.RemoveCurrent()
+        if E != nil {
+            return nil, time.Time{}, false, pushError(E, "ExpiringList::Remove: it.RemoveCurrent()")
+        }
+        return e.value, e.deadline, true, nil
+    }
+}   // End of function ExpiringList::Remove.
+
+/*
+ExpiringList::Len() returns the number of values in the list, including
+expired values which have not been purged yet.
+*/
+func (p *ExpiringList) Len() int {
+    //--------------------------//
+    //    ExpiringList::Len     //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("ExpiringList::Len")
+        return 0
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.list.Length()
+}   // End of function ExpiringList::Len.
+
+/*
+ExpiringList::PurgeExpired() removes all values whose deadlines are not after
+now, in list order, and returns their number. The expiry callback is called for
+each of them after they have been removed, without holding the lock of the list,
+so the callback may use the list.
+*/
+func (p *ExpiringList) PurgeExpired(now time.Time) (int, error) {
+    //--------------------------------//
+    //   ExpiringList::PurgeExpired   //
+    //--------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "ExpiringList::PurgeExpired: p == nil")
+    }
+    var expired []*expiring_entry
+    p.mu.Lock()
+    var it List_iter
+    it.Init(&p.list)
+    for {
+        q, E := it.Next()
+        if E != nil {
+            p.mu.Unlock()
+            return 0, pushError(E, "ExpiringList::PurgeExpired: it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        e := q.value.(*expiring_entry)
+        if e.deadline.After(now) {
+            continue
+        }
+        _, E = it.RemoveCurrent()
+        if E != nil {
+            p.mu.Unlock()
+            return 0, pushError(E, "ExpiringList::PurgeExpired: it.RemoveCurrent()")
+        }
+        expired = append(expired, e)
+    }
+    p.mu.Unlock()
+    if p.on_expire != nil {
+        for _, e := range expired {
+            p.on_expire(e.value, e.deadline)
+        }
+    }
+    return len(expired), nil
+}   // End of function ExpiringList::PurgeExpired.
+
+/*
+ExpiringList::Run() calls ExpiringList::PurgeExpired() with the current time at
+the given interval until stop is closed or an error occurs. It is normally
+started in its own goroutine.
+*/
+func (p *ExpiringList) Run(interval time.Duration, stop <-chan struct{}) error {
+    //--------------------------//
+    //    ExpiringList::Run     //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "ExpiringList::Run: p == nil")
+    }
+    if interval <= 0 {
+        return newError(ErrInvalidArgument, "ExpiringList::Run: interval <= 0")
+    }
+    tick := time.NewTicker(interval)
+    defer tick.Stop()
+    for {
+        select {
+        case <-stop:
+            return nil
+        case now := <-tick.C:
+            _, E := p.PurgeExpired(now)
+            if E != nil {
+                return pushError(E, "ExpiringList::Run: p.PurgeExpired()")
+            }
+        }
+    }
+}   // End of function ExpiringList::Run.
@@ -0,0 +1,295 @@
+// src/go/s2list_extsort.go   2026-10-17
+// External merge sort of s2list lists through temporary files.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SortExternal
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+sort_run::write
+sort_run::open
+sort_run::next
+sort_run::close
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "bufio"
+import "container/heap"
+import "encoding/binary"
+import "io"
+import "os"
+import "sort"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A sort_run is a sorted run of encoded values in a temporary file. Each record
+is a little-endian uint32 length followed by the encoded value.
+*/
+type sort_run struct {
+    name string        // The name of the temporary file.
+    file *os.File      // The open file while the run is being read.
+    in   *bufio.Reader // Buffered reader of the file.
+}
+
+/*
+List_base::SortExternal() sorts the list stably according to less, for lists
+whose values are too large to sort in memory. The values are encoded with vc in
+runs of at most memLimit encoded bytes (at least one value per run). Each run is
+sorted and written to a temporary file in the directory tmpDir, or in the
+default directory for temporary files if tmpDir is empty. The list is then
+emptied, and the runs are merged back into it with the k-way merge heap which
+MergeIter uses. Equal values keep their relative order. If less is nil, the
+values are ordered by the Less function which was registered for the type of
+the first value, and nothing is sorted if that type has none.
+If all values fit into a single run, no files are written, and the nodes are
+relinked in sorted order instead. Otherwise the values are decoded into new
+nodes. The old nodes are cast adrift, and their metadata is not carried over.
+If an error occurs while the runs are written, the list is not changed. An error
+while merging leaves the list with the values which were merged so far. The
+temporary files are always removed.
+*/
+func (p *List_base) SortExternal(less func(a, b interface{}) bool, vc Value_codec,
+    tmpDir string, memLimit int) error {
+    //------------------------------//
+    //   List_base::SortExternal    //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SortExternal: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::SortExternal", time.Now())
+    }
+    if vc == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::SortExternal: vc == nil")
+    }
+    if memLimit <= 0 {
+        return p.fail(ErrInvalidArgument, nil, "List_base::SortExternal: memLimit <= 0")
+    }
+    if p.first != nil && p.last == nil {
+        return p.fail(ErrCorruptList, nil, "List_base::SortExternal: p.first != p.last == nil")
+    }
+    E := p.writable("List_base::SortExternal")
+    if E != nil {
+        return E
+    }
+    if p.first == nil {
+        return nil
+    }
+    less, E = resolveLess("List_base::SortExternal", less, p.first.value)
+    if E != nil {
+        return E
+    }
+    var runs []*sort_run
+    defer func() {
+        for _, r := range runs {
+            r.close()
+            os.Remove(r.name)
+        }
+    }()
+
+    // Write the sorted runs without changing the list.
+    var nodes []*List_node
+    var enc [][]byte
+    var size int = 0
+    for q := p.first; ; q = q.next {
+        if q != nil {
+            if q.base != p {
+                return p.fail(ErrCorruptList, q, "List_base::SortExternal: q.base != p")
+            }
+            b, E := vc.EncodeValue(q.value)
+            if E != nil {
+                return pushError(E, "List_base::SortExternal: vc.EncodeValue()")
+            }
+            nodes = append(nodes, q)
+            enc = append(enc, b)
+            size += len(b)
+            if size < memLimit {
+                continue
+            }
+        }
+        if len(nodes) == 0 {
+            break
+        }
+        order := make([]int, len(nodes))
+        for i := range order {
+            order[i] = i
+        }
+        sort.SliceStable(order, func(i, j int) bool {
+            return less(nodes[order[i]].value, nodes[order[j]].value)
+        })
+        if q == nil && len(runs) == 0 {
+            // Everything fits into memory. Relink the nodes.
+            E = p.modify("List_base::SortExternal")
+            if E != nil {
+                return E
+            }
+            p.takeChain()
+            for _, i := range order {
+                p.putChain(nodes[i], nodes[i])
+            }
+            return nil
+        }
+        r := &sort_run{}
+        runs = append(runs, r)
+        E = r.write(tmpDir, enc, order)
+        if E != nil {
+            return pushError(E, "List_base::SortExternal: r.write()")
+        }
+        nodes = nodes[:0]
+        enc = enc[:0]
+        size = 0
+        if q == nil {
+            break
+        }
+    }
+    if len(runs) == 0 {
+        return nil
+    }
+
+    // Replace the nodes by the merged runs.
+    E = p.modify("List_base::SortExternal")
+    if E != nil {
+        return E
+    }
+    q, _ := p.takeChain()
+    for q != nil {
+        next := q.next
+        q.unlink()
+        q = next
+    }
+    var h merge_heap
+    h.less = less
+    for i, r := range runs {
+        E = r.open()
+        if E != nil {
+            return pushError(E, "List_base::SortExternal: r.open()")
+        }
+        b, ok, E := r.next()
+        if E != nil {
+            return pushError(E, "List_base::SortExternal: r.next()")
+        }
+        if !ok {
+            continue
+        }
+        v, E := vc.DecodeValue(b)
+        if E != nil {
+            return pushError(E, "List_base::SortExternal: vc.DecodeValue()")
+        }
+        h.heads = append(h.heads, merge_head{value: v, src: i})
+    }
+    heap.Init(&h)
+    for len(h.heads) > 0 {
+        head := h.heads[0]
+        E = p.AppendValue(head.value)
+        if E != nil {
+            return pushError(E, "List_base::SortExternal: p.AppendValue()")
+        }
+        b, ok, E := runs[head.src].next()
+        if E != nil {
+            return pushError(E, "List_base::SortExternal: r.next()")
+        }
+        if !ok {
+            heap.Pop(&h)
+            continue
+        }
+        v, E := vc.DecodeValue(b)
+        if E != nil {
+            return pushError(E, "List_base::SortExternal: vc.DecodeValue()")
+        }
+        h.heads[0].value = v
+        heap.Fix(&h, 0)
+    }
+    return nil
+}   // End of function List_base::SortExternal.
+
+/*
+sort_run::write() writes the encoded values enc in the given order to a new
+temporary file in the directory dir.
+*/
+func (p *sort_run) write(dir string, enc [][]byte, order []int) error {
+    //----------------------//
+    //    sort_run::write   //
+    //----------------------//
+    f, E := os.CreateTemp(dir, "s2list-run-*")
+    if E != nil {
+        return pushError(E, "sort_run::write: os.CreateTemp()")
+    }
+    p.name = f.Name()
+    out := bufio.NewWriter(f)
+    var hdr [4]byte
+    for _, i := range order {
+        if uint64(len(enc[i])) > 0xffffffff {
+            f.Close()
+            return newError(ErrInvalidArgument, "sort_run::write: encoded value too long")
+        }
+        binary.LittleEndian.PutUint32(hdr[:], uint32(len(enc[i])))
+        out.Write(hdr[:])
+        out.Write(enc[i])
+    }
+    E = out.Flush()
+    if E != nil {
+        f.Close()
+        return pushError(E, "sort_run::write: out.Flush()")
+    }
+    E = f.Close()
+    if E != nil {
+        return pushError(E, "sort_run::write: f.Close()")
+    }
+    return nil
+}   // End of function sort_run::write.
+
+/*
+sort_run::open() opens the file of the run for reading.
+*/
+func (p *sort_run) open() error {
+    //----------------------//
+    //    sort_run::open    //
+    //----------------------//
+    f, E := os.Open(p.name)
+    if E != nil {
+        return pushError(E, "sort_run::open: os.Open()")
+    }
+    p.file = f
+    p.in = bufio.NewReader(f)
+    return nil
+}   // End of function sort_run::open.
+
+/*
+sort_run::next() returns the next encoded value of the run. The boolean return
+value is false at the end of the run.
+*/
+func (p *sort_run) next() ([]byte, bool, error) {
+    //----------------------//
+    //    sort_run::next    //
+    //----------------------//
+    var hdr [4]byte
+    _, E := io.ReadFull(p.in, hdr[:])
+    if E == io.EOF {
+        return nil, false, nil
+    }
+    if E != nil {
+        return nil, false, pushError(E, "sort_run::next: io.ReadFull(hdr)")
+    }
+    b := make([]byte, binary.LittleEndian.Uint32(hdr[:]))
+    _, E = io.ReadFull(p.in, b)
+    if E != nil {
+        return nil, false, pushError(E, "sort_run::next: io.ReadFull(b)")
+    }
+    return b, true, nil
+}   // End of function sort_run::next.
+
+/*
+sort_run::close() closes the file of the run if it is open.
+*/
+func (p *sort_run) close() {
+    //----------------------//
+    //    sort_run::close   //
+    //----------------------//
+    if p.file != nil {
+        p.file.Close()
+        p.file = nil
+    }
+}   // End of function sort_run::close.
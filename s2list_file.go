@@ -0,0 +1,126 @@
+// src/go/s2list_file.go   2026-10-17
+// Checkpoints of s2list lists in files.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SaveFile
+LoadFile
+syncDir
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "bufio"
+import "os"
+import "path/filepath"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::SaveFile() writes the values of the list to the file at path in the
+format of the codec, such as JSON_codec{}. The file is replaced atomically: the
+list is written to a temporary file in the same directory, which is synced to
+the disk and then renamed to path. So after a crash, the file holds either the
+previous checkpoint or the new one, never a partial one. The temporary file is
+removed if an error occurs. A new file gets the mode 0600, and a replaced file
+keeps its mode.
+*/
+func (p *List_base) SaveFile(path string, codec Codec) error {
+    //--------------------------//
+    //   List_base::SaveFile    //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SaveFile: p == nil")
+    }
+    if codec == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::SaveFile: codec == nil")
+    }
+    dir, name := filepath.Split(path)
+    if dir == "" {
+        dir = "."
+    }
+    f, E := os.CreateTemp(dir, "." + name + ".tmp*")
+    if E != nil {
+        return pushError(E, "List_base::SaveFile: os.CreateTemp()")
+    }
+    tmp := f.Name()
+    done := false
+    defer func() {
+        if !done {
+            f.Close()
+            os.Remove(tmp)
+        }
+    }()
+    if fi, E := os.Stat(path); E == nil {
+        E = f.Chmod(fi.Mode().Perm())
+        if E != nil {
+            return pushError(E, "List_base::SaveFile: f.Chmod()")
+        }
+    }
+    w := bufio.NewWriter(f)
+    E = codec.Encode(w, p)
+    if E != nil {
+        return pushError(E, "List_base::SaveFile: codec.Encode()")
+    }
+    E = w.Flush()
+    if E != nil {
+        return pushError(E, "List_base::SaveFile: w.Flush()")
+    }
+    E = f.Sync()
+    if E != nil {
+        return pushError(E, "List_base::SaveFile: f.Sync()")
+    }
+    E = f.Close()
+    if E != nil {
+        return pushError(E, "List_base::SaveFile: f.Close()")
+    }
+    E = os.Rename(tmp, path)
+    if E != nil {
+        return pushError(E, "List_base::SaveFile: os.Rename()")
+    }
+    done = true
+    // Make the rename itself durable.
+    return syncDir(dir)
+}   // End of function List_base::SaveFile.
+
+/*
+LoadFile() returns a new list with the values which were saved in the file at
+path by List_base::SaveFile() with the same codec.
+*/
+func LoadFile(path string, codec Codec) (*List_base, error) {
+    //----------------------//
+    //       LoadFile       //
+    //----------------------//
+    if codec == nil {
+        return nil, newError(ErrNilArgument, "LoadFile: codec == nil")
+    }
+    f, E := os.Open(path)
+    if E != nil {
+        return nil, pushError(E, "LoadFile: os.Open()")
+    }
+    defer f.Close()
+    p, E := codec.Decode(bufio.NewReader(f))
+    if E != nil {
+        return nil, pushError(E, "LoadFile: codec.Decode()")
+    }
+    return p, nil
+}   // End of function LoadFile.
+
+/*
+syncDir() is a private function for internal use in this package.
+It syncs the directory dir to the disk, so that a rename in it survives a
+crash. Systems which cannot sync directories are not reported as errors.
+*/
+func syncDir(dir string) error {
+    //----------------------//
+    //        syncDir       //
+    //----------------------//
+    d, E := os.Open(dir)
+    if E != nil {
+        return pushError(E, "syncDir: os.Open()")
+    }
+    defer d.Close()
+    d.Sync()
+    return nil
+}   // End of function syncDir.
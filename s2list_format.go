@@ -0,0 +1,129 @@
+// src/go/s2list_format.go   2026-10-17
+// Text and fmt formatting of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::MarshalText
+List_base::Format
+List_base::formatTo
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "fmt"
+import "io"
+import "strings"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::MarshalText() implements encoding.TextMarshaler. The text is the
+values of the list in order, formatted with the %v verb of package fmt and
+separated by spaces within square brackets, like the %v format of a slice,
+such as "[1 two 3]". The traversal has the integrity checks of List_iter::Next(),
+so a corrupt list returns an error. There is no UnmarshalText() because the
+types of the values cannot be recovered from the text.
+*/
+func (p *List_base) MarshalText() ([]byte, error) {
+    //------------------------------//
+    //    List_base::MarshalText    //
+    //------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::MarshalText: p == nil")
+    }
+    var sb strings.Builder
+    sb.WriteByte('[')
+    sep := ""
+    for q, E := range p.All() {
+        if E != nil {
+            return nil, pushError(E, "List_base::MarshalText: p.All()")
+        }
+        fmt.Fprintf(&sb, "%s%v", sep, q.value)
+        sep = " "
+    }
+    sb.WriteByte(']')
+    return []byte(sb.String()), nil
+}   // End of function List_base::MarshalText.
+
+/*
+List_base::Format() implements fmt.Formatter, so that lists can be logged.
+    %v   the values, like List_base::MarshalText(), such as [1 2 3].
+    %+v  the address and value of each node, such as [0xc000010018:1 ...].
+    %#v  the name, first-pointer and last-pointer of the list, and the
+         address, base-pointer and value of each node.
+The %s verb is the same as %v. Since the output is for diagnostics of possibly
+corrupt lists, it never fails and has no side effects. The nodes are visited
+without the integrity checks of List_iter::Next(), and defects are marked in
+the output instead: "<cycle>" where the chain returns to a node which was
+printed before, "!base" after a node whose base-pointer is not the list, and
+"!last" if the last-pointer is not the final node of the chain.
+*/
+func (p *List_base) Format(f fmt.State, verb rune) {
+    //----------------------//
+    //   List_base::Format  //
+    //----------------------//
+    switch verb {
+    case 'v', 's':
+    default:
+        fmt.Fprintf(f, "%%!%c(*s2list.List_base)", verb)
+        return
+    }
+    if p == nil {
+        io.WriteString(f, "<nil>")
+        return
+    }
+    p.formatTo(f, f.Flag('+'), f.Flag('#') && verb == 'v')
+}   // End of function List_base::Format.
+
+/*
+List_base::formatTo() is a private member function for internal use in this
+package.
+It writes the output of List_base::Format() to w. The flag addr adds the
+addresses of the nodes, and diag adds the base-pointers and the pointers of the
+list.
+*/
+func (p *List_base) formatTo(w io.Writer, addr bool, diag bool) {
+    //--------------------------//
+    //   List_base::formatTo    //
+    //--------------------------//
+    if diag {
+        fmt.Fprintf(w, "s2list.List_base{name:%q, first:%p, last:%p, nodes:",
+            p.name, p.first, p.last)
+    }
+    io.WriteString(w, "[")
+    seen := make(map[*List_node]bool)
+    var prev *List_node
+    for q := p.first; q != nil; q = q.next {
+        if prev != nil {
+            io.WriteString(w, " ")
+        }
+        if seen[q] {
+            io.WriteString(w, "<cycle>")
+            break
+        }
+        seen[q] = true
+        switch {
+        case diag:
+            fmt.Fprintf(w, "%p(base=%p):%v", q, q.base, q.value)
+        case addr:
+            fmt.Fprintf(w, "%p:%v", q, q.value)
+        default:
+            fmt.Fprintf(w, "%v", q.value)
+        }
+        if q.base != p {
+            io.WriteString(w, "!base")
+        }
+        prev = q
+        if q.next == poison_node {
+            break
+        }
+    }
+    io.WriteString(w, "]")
+    if prev != p.last {
+        io.WriteString(w, "!last")
+    }
+    if diag {
+        io.WriteString(w, "}")
+    }
+}   // End of function List_base::formatTo.
@@ -0,0 +1,206 @@
+// src/go/s2list_frame.go   2026-10-17
+// Reassembly of protocol frames from byte chunks in a rope.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+LengthPrefixed
+Delimited
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+NewFrameBuffer
+FrameBuffer::
+FrameBuffer::Len
+FrameBuffer::Write
+FrameBuffer::Pop
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "bytes"
+import "encoding/binary"
+import "io"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Frame_func finds the first complete frame in the n buffered bytes which are
+read from r. It returns the payload of the frame as the byte range from start
+to end, and the number of bytes advance which the frame occupies, including
+its header and trailer. An advance of zero means that the frame is not yet
+complete. An error means that the data cannot be framed.
+*/
+type Frame_func func(r io.ReaderAt, n int) (start, end, advance int, err error)
+
+/*
+LengthPrefixed() returns a Frame_func for frames which start with an unsigned
+length of 1, 2, 4 or 8 bytes in the given byte order, followed by that many
+bytes of payload. A length greater than max is an error of kind ErrBadFormat.
+If max is zero or less, the length is not limited.
+*/
+func LengthPrefixed(size int, order binary.ByteOrder, max int) (Frame_func, error) {
+    //----------------------//
+    //    LengthPrefixed    //
+    //----------------------//
+    if size != 1 && size != 2 && size != 4 && size != 8 {
+        return nil, newError(ErrInvalidArgument, "LengthPrefixed: size not 1, 2, 4 or 8")
+    }
+    if order == nil {
+        return nil, newError(ErrNilArgument, "LengthPrefixed: order == nil")
+    }
+    frame := func(r io.ReaderAt, n int) (int, int, int, error) {
+        if n < size {
+            return 0, 0, 0, nil
+        }
+        var hdr [8]byte
+        _, E := r.ReadAt(hdr[:size], 0)
+        if E != nil {
+            return 0, 0, 0, pushError(E, "LengthPrefixed: r.ReadAt(hdr)")
+        }
+        var length uint64
+        switch size {
+        case 1:
+            length = uint64(hdr[0])
+        case 2:
+            length = uint64(order.Uint16(hdr[:2]))
+        case 4:
+            length = uint64(order.Uint32(hdr[:4]))
+        default:
+            length = order.Uint64(hdr[:8])
+        }
+        if (max > 0 && length > uint64(max)) || length > uint64(int(^uint(0) >> 1) - size) {
+            return 0, 0, 0, newError(ErrBadFormat, "LengthPrefixed: frame too long")
+        }
+        end := size + int(length)
+        if n < end {
+            return 0, 0, 0, nil
+        }
+        return size, end, end, nil
+    }
+    return frame, nil
+}   // End of function LengthPrefixed.
+
+/*
+Delimited() returns a Frame_func for frames which end with the given non-empty
+delimiter. The payload excludes the delimiter. The buffered bytes are scanned
+from the start on each call, so the cost of a frame grows with the number of
+calls which find it incomplete.
+*/
+func Delimited(delim []byte) (Frame_func, error) {
+    //----------------------//
+    //       Delimited      //
+    //----------------------//
+    if len(delim) == 0 {
+        return nil, newError(ErrInvalidArgument, "Delimited: len(delim) == 0")
+    }
+    delim = bytes.Clone(delim)
+    frame := func(r io.ReaderAt, n int) (int, int, int, error) {
+        buf := make([]byte, n)
+        _, E := r.ReadAt(buf, 0)
+        if E != nil && E != io.EOF {
+            return 0, 0, 0, pushError(E, "Delimited: r.ReadAt(buf)")
+        }
+        i := bytes.Index(buf, delim)
+        if i < 0 {
+            return 0, 0, 0, nil
+        }
+        return 0, i, i + len(delim), nil
+    }
+    return frame, nil
+}   // End of function Delimited.
+
+/*
+A FrameBuffer reassembles frames from a byte stream which arrives in chunks of
+arbitrary size, such as the reads from a TCP connection. The chunks are
+appended to a rope, and complete frames are popped from the front.
+    data  *Rope      // The buffered bytes.
+    frame Frame_func // The framing of the stream.
+A FrameBuffer is an io.Writer, so it can be filled with io.Copy().
+*/
+type FrameBuffer struct {
+    //--------------------------//
+    //      FrameBuffer::       //
+    //--------------------------//
+    data  *Rope      // The buffered bytes.
+    frame Frame_func // The framing of the stream.
+}
+
+/*
+NewFrameBuffer() returns an empty frame buffer with the given framing. The
+argument chunk is the maximum chunk length of the rope. See NewRope().
+*/
+func NewFrameBuffer(frame Frame_func, chunk int) (*FrameBuffer, error) {
+    //--------------------------//
+    //      NewFrameBuffer      //
+    //--------------------------//
+    if frame == nil {
+        return nil, newError(ErrNilArgument, "NewFrameBuffer: frame == nil")
+    }
+    return &FrameBuffer{data: NewRope(chunk), frame: frame}, nil
+}   // End of function NewFrameBuffer.
+
+/*
+FrameBuffer::Len() returns the number of buffered bytes.
+*/
+func (p *FrameBuffer) Len() int {
+    //----------------------//
+    //   FrameBuffer::Len   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("FrameBuffer::Len")
+        return 0
+    }
+    return p.data.Len()
+}   // End of function FrameBuffer::Len.
+
+/*
+FrameBuffer::Write() appends a copy of the bytes b to the buffer, as io.Writer
+requires.
+*/
+func (p *FrameBuffer) Write(b []byte) (int, error) {
+    //--------------------------//
+    //    FrameBuffer::Write    //
+    //--------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "FrameBuffer::Write: p == nil")
+    }
+    E := p.data.Append(b)
+    if E != nil {
+        return 0, pushError(E, "FrameBuffer::Write: p.data.Append(b)")
+    }
+    return len(b), nil
+}   // End of function FrameBuffer::Write.
+
+/*
+FrameBuffer::Pop() removes the first complete frame from the buffer and returns
+its payload. The return value is nil if no complete frame is buffered. After an
+error of the framing function, the buffer is left unchanged.
+*/
+func (p *FrameBuffer) Pop() ([]byte, error) {
+    //----------------------//
+    //   FrameBuffer::Pop   //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "FrameBuffer::Pop: p == nil")
+    }
+    n := p.data.Len()
+    start, end, advance, E := p.frame(p.data, n)
+    if E != nil {
+        return nil, pushError(E, "FrameBuffer::Pop: p.frame(p.data, n)")
+    }
+    if advance == 0 {
+        return nil, nil
+    }
+    if start < 0 || start > end || end > advance || advance > n {
+        return nil, newError(ErrInvalidArgument, "FrameBuffer::Pop: frame out of range")
+    }
+    payload := make([]byte, end - start)
+    _, E = p.data.ReadAt(payload, int64(start))
+    if E != nil {
+        return nil, pushError(E, "FrameBuffer::Pop: p.data.ReadAt(payload)")
+    }
+    E = p.data.DeleteRange(0, advance)
+    if E != nil {
+        return nil, pushError(E, "FrameBuffer::Pop: p.data.DeleteRange(0, advance)")
+    }
+    return payload, nil
+}   // End of function FrameBuffer::Pop.
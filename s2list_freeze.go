@@ -0,0 +1,68 @@
+// src/go/s2list_freeze.go   2026-10-17
+// Permanently immutable s2list lists, and a read-only view of them.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Freeze
+List_base::IsFrozen
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "iter"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A ReadOnlyList is the read-only subset of the methods of a List_base. A list
+can be handed to code which should only read it as a ReadOnlyList. Since the
+receiver can still convert it back to *List_base, the list should also be
+frozen with List_base::Freeze() if the guarantee must hold against untrusted
+code, such as plugins.
+*/
+type ReadOnlyList interface {
+    Empty() bool
+    GetFirst() *List_node
+    Length() int
+    FirstValue() (interface{}, bool)
+    LastValue() (interface{}, bool)
+    All() iter.Seq2[*List_node, error]
+    ReverseAll() iter.Seq2[*List_node, error]
+}
+
+// A List_base is a ReadOnlyList.
+var _ ReadOnlyList = (*List_base)(nil)
+
+/*
+List_base::Freeze() makes the list permanently immutable. Afterwards, every
+method which would modify the structure of the list or the value of one of its
+nodes returns ErrFrozen, including List_node::SetValue() for the nodes of the
+list. There is no way to unfreeze a list. Freezing a frozen list has no
+effect. Copies of the list are not frozen.
+*/
+func (p *List_base) Freeze() error {
+    //----------------------//
+    //   List_base::Freeze  //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::Freeze: p == nil")
+    }
+    p.frozen = true
+    return nil
+}   // End of function List_base::Freeze.
+
+/*
+List_base::IsFrozen() returns true if the list has been frozen by
+List_base::Freeze().
+*/
+func (p *List_base) IsFrozen() bool {
+    //----------------------//
+    //  List_base::IsFrozen //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::IsFrozen")
+        return false
+    }
+    return p.frozen
+}   // End of function List_base::IsFrozen.
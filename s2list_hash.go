@@ -0,0 +1,63 @@
+// src/go/s2list_hash.go   2026-10-17
+// Order-sensitive content hashing of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Hash
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/binary"
+import "hash/fnv"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::Hash() returns an order-sensitive digest of the values of the list.
+The function h hashes a single value. If h is nil, each value is hashed with
+the Hash function which was registered for its type, and an error is returned
+for the first value whose type has none. The value hashes are chained with
+64-bit FNV-1a, so two lists with the same values in a different order almost
+always have different digests. The digest of an empty list is the FNV-1a
+offset basis. The digest only changes when the values change, so it can be
+used to detect whether the contents of a list changed between two points in
+time.
+*/
+func (p *List_base) Hash(h func(interface{}) uint64) (uint64, error) {
+    //----------------------//
+    //    List_base::Hash   //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::Hash: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Hash", time.Now())
+    }
+    digest := fnv.New64a()
+    var buf [8]byte
+    var it List_iter
+    it.Init(p)
+    for {
+        q, E := it.Next()
+        if E != nil {
+            return 0, pushError(E, "List_base::Hash: it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        hf := h
+        if hf == nil {
+            c, ok := LookupComparator(q.value)
+            if !ok || c.Hash == nil {
+                return 0, p.fail(ErrNilArgument, nil, "List_base::Hash: h == nil and no Hash registered for the type")
+            }
+            hf = c.Hash
+        }
+        binary.LittleEndian.PutUint64(buf[:], hf(q.value))
+        digest.Write(buf[:])
+    }
+    return digest.Sum64(), nil
+}   // End of function List_base::Hash.
@@ -0,0 +1,271 @@
+// src/go/s2list_heap.go   2026-10-17
+// Adapter of s2list lists for container/heap.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Heap_adapter::
+List_base::HeapAdapter
+Heap_adapter::Len
+Heap_adapter::Less
+Heap_adapter::Swap
+Heap_adapter::Push
+Heap_adapter::Pop
+Heap_adapter::Err
+Heap_adapter::valid
+Heap_adapter::link
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "container/heap"
+import "sync/atomic"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Heap_adapter implements heap.Interface for a list, so that the functions of
+container/heap maintain the list as a binary heap:
+    h, E := b.HeapAdapter(less)
+    heap.Init(h)
+    heap.Push(h, v)
+    q := heap.Pop(h).(*List_node)
+The list is always in the order of the heap array, which the adapter keeps in
+an index of the nodes, so the first node holds the least value. Every swap
+relinks the two nodes in O(1) time with their predecessors from the index, and
+the popped node is always the last node, so the heap operations keep their
+O(log n) cost. The nodes are inserted and removed with the integrity checks,
+element type checks, hooks and watchers of the list.
+Since the methods of heap.Interface have no error results, the first error is
+kept, and the adapter does nothing afterwards. See Heap_adapter::Err(). The
+list must not be modified other than through the adapter, or the adapter fails
+with ErrConcurrentModification.
+    list     *List_base                  // The list.
+    nodes    []*List_node                // The nodes in list order.
+    less     func(a, b interface{}) bool // The ordering of the values.
+    modcount uint64                      // The ModCount() of the index.
+    err      error                       // The first error, or nil.
+*/
+type Heap_adapter struct {
+    //----------------------//
+    //    Heap_adapter::    //
+    //----------------------//
+    list     *List_base                  // The list.
+    nodes    []*List_node                // The nodes in list order.
+    less     func(a, b interface{}) bool // The ordering of the values.
+    modcount uint64                      // The ModCount() of the index.
+    err      error                       // The first error, or nil.
+}
+
+// A Heap_adapter is a heap.Interface.
+var _ heap.Interface = (*Heap_adapter)(nil)
+
+/*
+List_base::HeapAdapter() returns a heap adapter for the list, with an index of
+its nodes in their current order. Call heap.Init() before the other heap
+functions unless the list is already ordered as a heap. The list must not have
+a length limit, because its evictions would bypass the adapter. See
+List_base::SetMaxLen(). The less function must not be nil, because the type of
+the values is not known in advance.
+*/
+func (p *List_base) HeapAdapter(less func(a, b interface{}) bool) (*Heap_adapter, error) {
+    //------------------------------//
+    //    List_base::HeapAdapter    //
+    //------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::HeapAdapter: p == nil")
+    }
+    if less == nil {
+        return nil, p.fail(ErrNilArgument, nil, "List_base::HeapAdapter: less == nil")
+    }
+    if p.max_len > 0 {
+        return nil, p.fail(ErrInvalidArgument, nil, "List_base::HeapAdapter: list has a length limit")
+    }
+    E := p.writable("List_base::HeapAdapter")
+    if E != nil {
+        return nil, E
+    }
+    a := &Heap_adapter{list: p, less: less, modcount: p.modcount}
+    for q := p.first; q != nil; q = q.next {
+        if q.base != p {
+            return nil, p.fail(ErrCorruptList, q, "List_base::HeapAdapter: q.base != p")
+        }
+        a.nodes = append(a.nodes, q)
+    }
+    if len(a.nodes) > 0 && a.nodes[len(a.nodes) - 1] != p.last {
+        return nil, p.fail(ErrCorruptList, nil, "List_base::HeapAdapter: p.last is not the last node")
+    }
+    return a, nil
+}   // End of function List_base::HeapAdapter.
+
+/*
+Heap_adapter::Len() returns the number of nodes in the heap.
+*/
+func (p *Heap_adapter) Len() int {
+    //----------------------//
+    //   Heap_adapter::Len  //
+    //----------------------//
+    return len(p.nodes)
+}   // End of function Heap_adapter::Len.
+
+/*
+Heap_adapter::Less() compares the values of the nodes at the positions i and j.
+*/
+func (p *Heap_adapter) Less(i, j int) bool {
+    //----------------------//
+    //  Heap_adapter::Less  //
+    //----------------------//
+    return p.less(p.nodes[i].value, p.nodes[j].value)
+}   // End of function Heap_adapter::Less.
+
+/*
+Heap_adapter::Swap() exchanges the nodes at the positions i and j by relinking
+them.
+*/
+func (p *Heap_adapter) Swap(i, j int) {
+    //----------------------//
+    //  Heap_adapter::Swap  //
+    //----------------------//
+    if i == j || !p.valid("Heap_adapter::Swap") {
+        return
+    }
+    p.err = p.list.modify("Heap_adapter::Swap")
+    if p.err != nil {
+        return
+    }
+    p.nodes[i], p.nodes[j] = p.nodes[j], p.nodes[i]
+    p.link(i - 1)
+    p.link(i)
+    p.link(j - 1)
+    p.link(j)
+    p.modcount = p.list.modcount
+    if p.list.watchers != nil {
+        // Report the moves in list order, after their new predecessors.
+        if i > j {
+            i, j = j, i
+        }
+        for _, k := range [2]int{i, j} {
+            var after *List_node
+            if k > 0 {
+                after = p.nodes[k - 1]
+            }
+            p.list.notify(Change_move, p.nodes[k], after)
+        }
+    }
+}   // End of function Heap_adapter::Swap.
+
+/*
+Heap_adapter::Push() appends x to the list, as the last element of the heap
+array. If x is a *List_node, the node is appended. Otherwise a new node with
+the value x is appended. This is called by heap.Push().
+*/
+func (p *Heap_adapter) Push(x interface{}) {
+    //----------------------//
+    //  Heap_adapter::Push  //
+    //----------------------//
+    if !p.valid("Heap_adapter::Push") {
+        return
+    }
+    q, ok := x.(*List_node)
+    if !ok {
+        q = &List_node{value: x}
+    }
+    p.err = p.list.Append(q)
+    if p.err != nil {
+        return
+    }
+    p.nodes = append(p.nodes, q)
+    p.modcount = p.list.modcount
+}   // End of function Heap_adapter::Push.
+
+/*
+Heap_adapter::Pop() removes the last node of the list, which is the last
+element of the heap array, and returns it as a *List_node. This is called by
+heap.Pop(), which returns the node with the least value. After an error, nil is
+returned.
+*/
+func (p *Heap_adapter) Pop() interface{} {
+    //----------------------//
+    //  Heap_adapter::Pop   //
+    //----------------------//
+    n := len(p.nodes)
+    if n == 0 || !p.valid("Heap_adapter::Pop") {
+        return nil
+    }
+    b := p.list
+    p.err = b.modify("Heap_adapter::Pop")
+    if p.err != nil {
+        return nil
+    }
+    q := p.nodes[n - 1]
+    var prev *List_node
+    if n > 1 {
+        prev = p.nodes[n - 2]
+    }
+    p.nodes[n - 1] = nil
+    p.nodes = p.nodes[:n - 1]
+    b.removeAfter(prev, q)
+    p.modcount = b.modcount
+    if b.stats != nil {
+        atomic.AddUint64(&b.stats.removals, 1)
+    }
+    b.left(q, false)
+    return q
+}   // End of function Heap_adapter::Pop.
+
+/*
+Heap_adapter::Err() returns the first error of the adapter, or nil.
+*/
+func (p *Heap_adapter) Err() error {
+    //----------------------//
+    //   Heap_adapter::Err  //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Heap_adapter::Err: p == nil")
+    }
+    return p.err
+}   // End of function Heap_adapter::Err.
+
+/*
+Heap_adapter::valid() is a private member function for internal use in this
+package.
+It returns true if the adapter may operate on the list. Otherwise it records
+ErrConcurrentModification if the list was modified behind the adapter.
+*/
+func (p *Heap_adapter) valid(fn string) bool {
+    //--------------------------//
+    //   Heap_adapter::valid    //
+    //--------------------------//
+    if p.err != nil {
+        return false
+    }
+    if p.list.modcount != p.modcount {
+        p.err = pushError(ErrConcurrentModification, fn + ": list was modified")
+        return false
+    }
+    return true
+}   // End of function Heap_adapter::valid.
+
+/*
+Heap_adapter::link() is a private member function for internal use in this
+package.
+It sets the next-pointer of the node at position k to the node at position
+k + 1, and the first-pointer or last-pointer of the list at the ends. Negative
+positions set only the first-pointer.
+*/
+func (p *Heap_adapter) link(k int) {
+    //--------------------------//
+    //    Heap_adapter::link    //
+    //--------------------------//
+    n := len(p.nodes)
+    if k < 0 {
+        p.list.first = p.nodes[0]
+        return
+    }
+    if k + 1 < n {
+        p.nodes[k].next = p.nodes[k + 1]
+    } else {
+        p.nodes[k].next = nil
+        p.list.last = p.nodes[k]
+    }
+}   // End of function Heap_adapter::link.
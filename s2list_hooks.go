@@ -0,0 +1,125 @@
+// src/go/s2list_hooks.go   2026-10-17
+// Synchronous mutation hooks for s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SetHooks
+List_base::GetHooks
+Hooks::inserted
+Hooks::removed
+Hooks::cleared
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Hooks holds the callbacks which List_base::SetHooks() registers with a list.
+Any of the functions may be nil.
+    OnInsert func(q *List_node) // Called after q has joined the list.
+    OnRemove func(q *List_node) // Called after q has left the list.
+    OnClear  func()             // Called after List_base::Clear().
+*/
+type Hooks struct {
+    OnInsert func(q *List_node) // Called after q has joined the list.
+    OnRemove func(q *List_node) // Called after q has left the list.
+    OnClear  func()             // Called after List_base::Clear().
+}
+
+/*
+List_base::SetHooks() registers callbacks which are invoked synchronously after
+every change of the membership of the list, so that a wrapping type can keep
+derived state, such as an index, a counter or an invariant, without wrapping
+every method of the list.
+OnInsert is called for every node which joins the list, and OnRemove for every
+node which leaves it, by every method which inserts or removes nodes. These are
+the appends, prepends, pops and removals, List_base::Replace(),
+List_base::RequeueToBack(), List_iter::InsertAfterCurrent(),
+List_iter::RemoveCurrent(), the bulk removals such as List_base::Truncate(),
+List_base::Drop(), List_base::Dedup() and List_base::Unique(), and the
+evictions of a length limit. Methods which move chains of nodes from one list
+to another, such as List_base::TransferAllTo() and List_base::StealFirstN(),
+call OnRemove for each node which leaves the list, and OnInsert for each node
+which joins it. Methods which rebuild the list, such as
+List_base::SortExternal(), report all nodes as removed and inserted again.
+List_base::Clear() calls OnClear once, instead of OnRemove for each node.
+Moves of nodes within the list, changes of values and repairs of corrupt lists
+are not reported. They are counted by List_base::ModCount().
+The hooks must not modify the list. A zero Hooks value switches the hooks off.
+*/
+func (p *List_base) SetHooks(h Hooks) error {
+    //----------------------//
+    //  List_base::SetHooks //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetHooks: p == nil")
+    }
+    if h.OnInsert == nil && h.OnRemove == nil && h.OnClear == nil {
+        p.hooks = nil
+        return nil
+    }
+    p.hooks = &h
+    return nil
+}   // End of function List_base::SetHooks.
+
+/*
+List_base::GetHooks() returns the hooks of the list, so that a wrapper can
+chain its own hooks with the hooks which were registered before.
+*/
+func (p *List_base) GetHooks() Hooks {
+    //----------------------//
+    //  List_base::GetHooks //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::GetHooks")
+        return Hooks{}
+    }
+    if p.hooks == nil {
+        return Hooks{}
+    }
+    return *p.hooks
+}   // End of function List_base::GetHooks.
+
+/*
+Hooks::inserted() is a private member function for internal use in this
+package.
+It calls the OnInsert hook for the node q, if there is one.
+*/
+func (p *Hooks) inserted(q *List_node) {
+    //----------------------//
+    //    Hooks::inserted   //
+    //----------------------//
+    if p.OnInsert != nil {
+        p.OnInsert(q)
+    }
+}   // End of function Hooks::inserted.
+
+/*
+Hooks::removed() is a private member function for internal use in this
+package.
+It calls the OnRemove hook for the node q, if there is one.
+*/
+func (p *Hooks) removed(q *List_node) {
+    //----------------------//
+    //    Hooks::removed    //
+    //----------------------//
+    if p.OnRemove != nil {
+        p.OnRemove(q)
+    }
+}   // End of function Hooks::removed.
+
+/*
+Hooks::cleared() is a private member function for internal use in this
+package.
+It calls the OnClear hook, if there is one.
+*/
+func (p *Hooks) cleared() {
+    //----------------------//
+    //    Hooks::cleared    //
+    //----------------------//
+    if p.OnClear != nil {
+        p.OnClear()
+    }
+}   // End of function Hooks::cleared.
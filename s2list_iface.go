@@ -0,0 +1,69 @@
+// src/go/s2list_iface.go   2026-10-17
+// Interfaces for the method sets of the s2list types.
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Lister is the basic method set of a List_base: the methods of ReadOnlyList
+and the basic insertions and removals. Code which depends on a Lister instead
+of a *List_base can be tested with a mock list, or given a decorator which adds
+logging, metrics or locking to a List_base. The nodes are still concrete
+*List_node values, because their base-pointers are what keeps the structure of
+a list sound.
+*/
+type Lister interface {
+    ReadOnlyList
+    ValidLength() (int, int, int)
+    CountNilValues() int
+    ModCount() uint64
+    Found(q *List_node) (bool, error)
+    Append(pnode *List_node) error
+    AppendValue(v interface{}) error
+    Prepend(pnode *List_node) error
+    PrependValue(v interface{}) error
+    Popfirst() (*List_node, error)
+    Poplast() (*List_node, error)
+    PopfirstValue() (interface{}, bool, error)
+    PoplastValue() (interface{}, bool, error)
+    Remove(q *List_node) (*List_node, error)
+    Clear() error
+}
+
+/*
+A NodeRef is the method set of a List_node.
+*/
+type NodeRef interface {
+    GetNext() (*List_node, error)
+    GetValue() (interface{}, error)
+    SetValue(v interface{}) error
+    Pin() error
+    Unpin() error
+    Pinned() bool
+    Meta() (Node_meta, bool)
+}
+
+/*
+An Iterator is the method set of a List_iter after List_iter::Init() has been
+called.
+*/
+type Iterator interface {
+    Restart() error
+    ItemCount() int
+    ItemCountValid() (int, int, int)
+    Next() (*List_node, error)
+    Prev() (*List_node, error)
+    Peek() (*List_node, error)
+    Skip(n int) (int, error)
+    Seek(q *List_node) error
+    NextIndexed() (int, *List_node, error)
+    RemoveCurrent() (*List_node, error)
+    InsertAfterCurrent(v interface{}) error
+}
+
+// The types of this package implement the interfaces.
+var _ Lister = (*List_base)(nil)
+var _ NodeRef = (*List_node)(nil)
+var _ Iterator = (*List_iter)(nil)
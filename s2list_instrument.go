@@ -0,0 +1,140 @@
+// src/go/s2list_instrument.go   2026-10-17
+// Instrumentation hooks for s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SetName
+List_base::GetName
+List_base::SetSlowHook
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+slow_hook::observe
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Slow_event describes a list operation which took longer than the threshold
+which was given to List_base::SetSlowHook().
+    Op       string        // Name of the method, e.g. "List_base::Poplast".
+    Name     string        // Name of the list. See List_base::SetName().
+    Duration time.Duration // Duration of the operation.
+    Length   int           // Length of the list after the operation.
+The length is -1 if the chain of the list is cyclic.
+The fields map directly onto the attributes of a tracing span event. For
+example, an OpenTelemetry user can call span.AddEvent() from the hook.
+*/
+type Slow_event struct {
+    Op       string        // Name of the method, e.g. "List_base::Poplast".
+    Name     string        // Name of the list. See List_base::SetName().
+    Duration time.Duration // Duration of the operation.
+    Length   int           // Length of the list after the operation.
+}
+
+/*
+A slow_hook holds the slow-operation reporting parameters of a list.
+*/
+type slow_hook struct {
+    threshold time.Duration    // Operations taking longer are reported.
+    report    func(Slow_event) // The caller's hook.
+}
+
+/*
+List_base::SetName() sets the name of the list which is used in diagnostics.
+*/
+func (p *List_base) SetName(name string) error {
+    //----------------------//
+    //  List_base::SetName  //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetName: p == nil")
+    }
+    p.name = name
+    return nil
+}   // End of function List_base::SetName.
+
+/*
+List_base::GetName() returns the name of the list which is used in diagnostics.
+*/
+func (p *List_base) GetName() string {
+    //----------------------//
+    //  List_base::GetName  //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::GetName")
+        return ""
+    }
+    return p.name
+}   // End of function List_base::GetName.
+
+/*
+List_base::SetSlowHook() arranges for the function f to be called whenever an
+operation on the list takes longer than the given threshold. A nil function
+switches the reporting off. The hook is called synchronously at the end of the
+slow operation, so it should return quickly.
+The instrumented operations are the appends, prepends and pops, the searches,
+removals and length counts, and the O(n) operations which reorder, split,
+deduplicate, partition, hash or move the chain, such as List_base::Rotate(),
+List_base::Truncate(), List_base::SortExternal() and List_base::Dedup().
+This helps to find O(n) calls like List_base::Poplast() and List_base::Remove()
+hiding in latency-critical code paths.
+*/
+func (p *List_base) SetSlowHook(threshold time.Duration, f func(Slow_event)) error {
+    //--------------------------//
+    //  List_base::SetSlowHook  //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetSlowHook: p == nil")
+    }
+    if f == nil {
+        p.slow = nil
+        return nil
+    }
+    if threshold < 0 {
+        return p.fail(ErrInvalidArgument, nil, "List_base::SetSlowHook: threshold < 0")
+    }
+    p.slow = &slow_hook{threshold: threshold, report: f}
+    return nil
+}   // End of function List_base::SetSlowHook.
+
+/*
+slow_hook::observe() is called on return from an instrumented operation which
+started at time t0. The hook is called if the threshold was exceeded.
+*/
+func (p *slow_hook) observe(b *List_base, op string, t0 time.Time) {
+    //--------------------------//
+    //   slow_hook::observe     //
+    //--------------------------//
+    d := time.Since(t0)
+    if d <= p.threshold {
+        return
+    }
+    // Don't call List_base::Length() here. It is instrumented too, and it
+    // does not end on a cyclic chain. The fast pointer catches up with the
+    // slow one if there is a cycle.
+    var n int = 0
+    if b.lenValid() {
+        n = b.n_len
+    } else {
+        slow, fast := b.first, b.first
+        for fast != nil {
+            fast = fast.next
+            n += 1
+            if fast == nil {
+                break
+            }
+            fast = fast.next
+            n += 1
+            slow = slow.next
+            if fast == slow {
+                n = -1
+                break
+            }
+        }
+    }
+    p.report(Slow_event{Op: op, Name: b.name, Duration: d, Length: n})
+}   // End of function slow_hook::observe.
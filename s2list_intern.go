@@ -0,0 +1,177 @@
+// src/go/s2list_intern.go   2026-10-17
+// Interning of repeated values in s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+NewInterner
+Interner::
+Interner::Intern
+Interner::Stats
+Interner::Reset
+List_base::SetInterner
+internable
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "reflect"
+import "sync"
+
+//=============================================================================
+//=============================================================================
+
+/*
+An Interner replaces values by a shared instance of an equal value which it has
+seen before (hash-consing). A list with an interner interns the value of every
+node which is inserted. See List_base::SetInterner(). This saves memory when a
+list holds many equal strings, because all of the nodes then share one copy of
+the string data. An Interner may be shared by several lists and goroutines.
+    mu    sync.Mutex                  // Protects the other fields.
+    table map[interface{}]interface{} // The shared instances.
+    stats Intern_stats                // The statistics.
+Only values are interned whose equality is the equality of their contents,
+which are strings, booleans and numbers, and arrays and structs which are made
+only of these. Pointers, channels, interfaces and the like are equal only to
+themselves, so interning them would save nothing, while the table, which never
+shrinks, would keep the objects which they refer to alive.
+*/
+type Interner struct {
+    //----------------------//
+    //      Interner::      //
+    //----------------------//
+    mu    sync.Mutex                  // Protects the other fields.
+    table map[interface{}]interface{} // The shared instances.
+    stats Intern_stats                // The statistics.
+}
+
+/*
+An Intern_stats holds the statistics of an Interner.
+    Lookups uint64 // Number of values which were interned.
+    Hits    uint64 // Number of values which were replaced by a shared one.
+    Entries uint64 // Number of distinct shared values.
+    Saved   uint64 // Bytes of string data which are not duplicated.
+*/
+type Intern_stats struct {
+    Lookups uint64 // Number of values which were interned.
+    Hits    uint64 // Number of values which were replaced by a shared one.
+    Entries uint64 // Number of distinct shared values.
+    Saved   uint64 // Bytes of string data which are not duplicated.
+}
+
+/*
+NewInterner() returns an empty interner.
+*/
+func NewInterner() *Interner {
+    //----------------------//
+    //      NewInterner     //
+    //----------------------//
+    return &Interner{table: make(map[interface{}]interface{})}
+}   // End of function NewInterner.
+
+/*
+Interner::Intern() returns the shared instance of the value v. If there is none
+yet, v becomes the shared instance. Nil values and values of other types than
+those described at Interner are returned unchanged.
+*/
+func (p *Interner) Intern(v interface{}) interface{} {
+    //----------------------//
+    //   Interner::Intern   //
+    //----------------------//
+    if p == nil || v == nil || !internable(reflect.TypeOf(v)) {
+        return v
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.table == nil {
+        p.table = make(map[interface{}]interface{})
+    }
+    p.stats.Lookups += 1
+    if w, ok := p.table[v]; ok {
+        p.stats.Hits += 1
+        if s, ok := w.(string); ok {
+            p.stats.Saved += uint64(len(s))
+        }
+        return w
+    }
+    p.table[v] = v
+    p.stats.Entries += 1
+    return v
+}   // End of function Interner::Intern.
+
+/*
+Interner::Stats() returns a copy of the statistics of the interner.
+*/
+func (p *Interner) Stats() Intern_stats {
+    //----------------------//
+    //    Interner::Stats   //
+    //----------------------//
+    if p == nil {
+        return Intern_stats{}
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.stats
+}   // End of function Interner::Stats.
+
+/*
+Interner::Reset() forgets all shared instances and clears the statistics. Values
+which are already in lists keep sharing their instances.
+*/
+func (p *Interner) Reset() {
+    //----------------------//
+    //    Interner::Reset   //
+    //----------------------//
+    if p == nil {
+        return
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.table = make(map[interface{}]interface{})
+    p.stats = Intern_stats{}
+}   // End of function Interner::Reset.
+
+/*
+List_base::SetInterner() sets the interner which interns the values of nodes
+which are inserted into the list from now on, including nodes which are moved
+in from other lists, such as by List_base::TransferAllTo(). A nil interner
+switches interning off. Values which are already in the list are not interned.
+*/
+func (p *List_base) SetInterner(in *Interner) error {
+    //------------------------------//
+    //    List_base::SetInterner    //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetInterner: p == nil")
+    }
+    p.interner = in
+    return nil
+}   // End of function List_base::SetInterner.
+
+/*
+internable() is a private function for internal use in this package.
+It returns true if values of the type t may be interned. These are strings,
+booleans and numbers, and arrays and structs whose elements and fields are
+internable.
+*/
+func internable(t reflect.Type) bool {
+    //----------------------//
+    //      internable      //
+    //----------------------//
+    switch t.Kind() {
+    case reflect.String, reflect.Bool,
+        reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+        reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+        return true
+    case reflect.Array:
+        return internable(t.Elem())
+    case reflect.Struct:
+        for i := 0; i < t.NumField(); i += 1 {
+            if !internable(t.Field(i).Type) {
+                return false
+            }
+        }
+        return true
+    }
+    return false
+}   // End of function internable.
@@ -0,0 +1,235 @@
+// src/go/s2list_intrusive.go   2026-10-17
+// Intrusive s2list lists whose nodes are embedded in the elements.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Hook::
+Hook::Init
+Hook::Owner
+Hook::Node
+Hook::List
+Hook::Linked
+Hook::Unlink
+InitHooks
+List_base::AppendHook
+List_base::PrependHook
+List_base::RemoveHook
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Hook is a list node which is embedded in the struct of an element, so that
+the element can be put into a list without allocating a separate node:
+    type Job struct {
+        s2list.Hook
+        id int
+    }
+    j := &Job{id: 1}
+    j.Init(j)
+    E := b.AppendHook(&j.Hook)
+The value of the embedded node is the owner which was given to Hook::Init(), so
+the element is recovered from any node of the list with List_node::GetValue()
+or Hook::Owner(), and the nodes which are popped from the list are the
+embedded nodes. The owner is normally a pointer, which is stored in the value
+without an allocation. Like any node, a hook can be in only one list at a time.
+To be in several lists at once, an element has several hooks as named fields,
+each of which has its own base-pointer. For example, the entries of a cache
+can be in a bucket list of a hash table and in an LRU list:
+    type Entry struct {
+        bucket s2list.Hook // Membership of a bucket list.
+        lru    s2list.Hook // Membership of the LRU list.
+        key    string
+    }
+    e := &Entry{key: k}
+    E := s2list.InitHooks(e, &e.bucket, &e.lru)
+An entry which is evicted from the LRU list is found as the owner of the popped
+node, and then leaves its bucket list with Hook::Unlink(), which needs no
+reference to the bucket list.
+The value of the node should not be changed with List_node::SetValue(),
+or the owner is lost. There is no separate Hook for the callbacks of a list.
+See Hooks.
+    node List_node // The embedded node.
+*/
+type Hook struct {
+    //----------------------//
+    //        Hook::        //
+    //----------------------//
+    node List_node // The embedded node.
+}
+
+/*
+Hook::Init() sets the owner of the hook, which is normally the struct which
+embeds it. ErrNodeInOtherList is returned if the hook is in a list.
+*/
+func (p *Hook) Init(owner interface{}) error {
+    //----------------------//
+    //      Hook::Init      //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Hook::Init: p == nil")
+    }
+    if p.node.base != nil {
+        return p.node.base.fail(ErrNodeInOtherList, &p.node, "Hook::Init: hook is in a list")
+    }
+    p.node.value = owner
+    return nil
+}   // End of function Hook::Init.
+
+/*
+Hook::Owner() returns the owner of the hook. See Hook::Init().
+*/
+func (p *Hook) Owner() interface{} {
+    //----------------------//
+    //      Hook::Owner     //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Hook::Owner")
+        return nil
+    }
+    return p.node.value
+}   // End of function Hook::Owner.
+
+/*
+Hook::Node() returns the embedded node of the hook, for the methods which take
+nodes, such as List_base::Found() and List_iter::Seek().
+*/
+func (p *Hook) Node() *List_node {
+    //----------------------//
+    //      Hook::Node      //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Hook::Node")
+        return nil
+    }
+    return &p.node
+}   // End of function Hook::Node.
+
+/*
+Hook::List() returns the list which the hook is in, or nil.
+*/
+func (p *Hook) List() *List_base {
+    //----------------------//
+    //      Hook::List      //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Hook::List")
+        return nil
+    }
+    return p.node.base
+}   // End of function Hook::List.
+
+/*
+Hook::Linked() returns true if the hook is in a list.
+*/
+func (p *Hook) Linked() bool {
+    //----------------------//
+    //     Hook::Linked     //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Hook::Linked")
+        return false
+    }
+    return p.node.base != nil
+}   // End of function Hook::Linked.
+
+/*
+Hook::Unlink() removes the hook from the list which it is in, like
+List_base::RemoveHook(). A hook which is not in a list is left alone.
+*/
+func (p *Hook) Unlink() error {
+    //----------------------//
+    //     Hook::Unlink     //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Hook::Unlink: p == nil")
+    }
+    b := p.node.base
+    if b == nil {
+        return nil
+    }
+    E := b.RemoveHook(p)
+    if E != nil {
+        return pushError(E, "Hook::Unlink: b.RemoveHook(p)")
+    }
+    return nil
+}   // End of function Hook::Unlink.
+
+/*
+InitHooks() sets the owner of all the hooks, which are normally the hooks of
+one element for several lists. See Hook::Init(). If a hook is in a list, the
+error is returned, and the owners of the hooks before it have been set.
+*/
+func InitHooks(owner interface{}, hooks ...*Hook) error {
+    //----------------------//
+    //       InitHooks      //
+    //----------------------//
+    for _, h := range hooks {
+        E := h.Init(owner)
+        if E != nil {
+            return pushError(E, "InitHooks: h.Init(owner)")
+        }
+    }
+    return nil
+}   // End of function InitHooks.
+
+/*
+List_base::AppendHook() appends the embedded node of the hook h to the list,
+like List_base::Append().
+*/
+func (p *List_base) AppendHook(h *Hook) error {
+    //------------------------------//
+    //    List_base::AppendHook     //
+    //------------------------------//
+    if h == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::AppendHook: h == nil")
+    }
+    E := p.Append(&h.node)
+    if E != nil {
+        return pushError(E, "List_base::AppendHook: p.Append()")
+    }
+    return nil
+}   // End of function List_base::AppendHook.
+
+/*
+List_base::PrependHook() inserts the embedded node of the hook h at the front of
+the list, like List_base::Prepend().
+*/
+func (p *List_base) PrependHook(h *Hook) error {
+    //------------------------------//
+    //    List_base::PrependHook    //
+    //------------------------------//
+    if h == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::PrependHook: h == nil")
+    }
+    E := p.Prepend(&h.node)
+    if E != nil {
+        return pushError(E, "List_base::PrependHook: p.Prepend()")
+    }
+    return nil
+}   // End of function List_base::PrependHook.
+
+/*
+List_base::RemoveHook() removes the embedded node of the hook h from the list,
+like List_base::Remove(). ErrNotMember is returned if the hook is not in the
+list.
+*/
+func (p *List_base) RemoveHook(h *Hook) error {
+    //------------------------------//
+    //    List_base::RemoveHook     //
+    //------------------------------//
+    if h == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::RemoveHook: h == nil")
+    }
+    if p != nil && h.node.base != p {
+        return p.fail(ErrNotMember, &h.node, "List_base::RemoveHook: h is not in the list")
+    }
+    _, E := p.Remove(&h.node)
+    if E != nil {
+        return pushError(E, "List_base::RemoveHook: p.Remove()")
+    }
+    return nil
+}   // End of function List_base::RemoveHook.
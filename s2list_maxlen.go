@@ -0,0 +1,153 @@
+// src/go/s2list_maxlen.go   2026-10-17
+// Length limit with automatic eviction for s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::SetMaxLen
+List_base::MaxLen
+List_base::lenValid
+List_base::full
+List_base::trim
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::SetMaxLen() limits the length of the list to n nodes, which turns
+the list into a bounded history buffer. When List_base::Append() makes the list
+longer than n, the first node is popped and handed to onEvict. When
+List_base::Prepend() makes the list longer than n, the last node is popped
+instead. If the list is already longer than n, the surplus nodes are evicted
+from the front at once. A limit of zero or less switches the limit off.
+The evicted nodes are popped with List_base::Popfirst() or
+List_base::Poplast(), so they are reported like other pops, and onEvict owns
+them afterwards. It may be nil, in which case the nodes are discarded. It must
+not modify the list.
+Nodes which are moved into the list from other lists, such as by
+List_base::TransferAllTo(), List_base::AttachAll(), List_base::StealFirstN(),
+SwapContents() or a dead-letter list of List_base::SetMaxAttempts(), are
+appended, so the surplus is evicted from the front after the move.
+List_iter::InsertAfterCurrent() cannot evict without disturbing the iteration,
+so it fails with ErrInvalidArgument while the list is full. The length is
+tracked in O(1) while the list is only modified by appends, prepends and
+evictions. Any other modification costs one count of the nodes at the next
+insertion.
+*/
+func (p *List_base) SetMaxLen(n int, onEvict func(*List_node)) error {
+    //--------------------------//
+    //   List_base::SetMaxLen   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetMaxLen: p == nil")
+    }
+    if n <= 0 {
+        p.max_len = 0
+        p.on_evict = nil
+        return nil
+    }
+    if p.origin != nil || p.readonly {
+        return p.fail(ErrReadOnly, nil, "List_base::SetMaxLen: p is read-only")
+    }
+    p.max_len = n
+    p.on_evict = onEvict
+    E := p.trim("List_base::SetMaxLen", false, true)
+    if E != nil {
+        return E
+    }
+    return nil
+}   // End of function List_base::SetMaxLen.
+
+/*
+List_base::MaxLen() returns the length limit of the list, or zero if there is
+none.
+*/
+func (p *List_base) MaxLen() int {
+    //----------------------//
+    //  List_base::MaxLen   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::MaxLen")
+        return 0
+    }
+    return p.max_len
+}   // End of function List_base::MaxLen.
+
+/*
+List_base::lenValid() is a private member function for internal use in this
+package.
+It returns true if the tracked length of the list is up to date. Like
+List_base::predsValid(), it must be called before List_base::modify().
+*/
+func (p *List_base) lenValid() bool {
+    //--------------------------//
+    //   List_base::lenValid    //
+    //--------------------------//
+    return p.max_len > 0 && p.len_mod == p.modcount
+}   // End of function List_base::lenValid.
+
+/*
+List_base::full() is a private member function for internal use in this
+package.
+It returns true if the list has reached its length limit. The nodes are counted
+if the tracked length is not up to date.
+*/
+func (p *List_base) full() bool {
+    //----------------------//
+    //    List_base::full   //
+    //----------------------//
+    if !p.lenValid() {
+        p.n_len = 0
+        for q := p.first; q != nil; q = q.next {
+            p.n_len += 1
+        }
+        p.len_mod = p.modcount
+    }
+    return p.n_len >= p.max_len
+}   // End of function List_base::full.
+
+/*
+List_base::trim() is a private member function for internal use in this
+package.
+It is called after a node has been inserted, while the length limit is set.
+The argument counted tells whether the tracked length was up to date before the
+insertion. Surplus nodes are evicted from the front, or from the back if front
+is false.
+*/
+func (p *List_base) trim(fn string, counted, front bool) error {
+    //----------------------//
+    //    List_base::trim   //
+    //----------------------//
+    if counted {
+        p.n_len += 1
+    } else {
+        p.n_len = 0
+        for q := p.first; q != nil; q = q.next {
+            p.n_len += 1
+        }
+    }
+    p.len_mod = p.modcount
+    for p.n_len > p.max_len {
+        var q *List_node
+        var E error
+        if front {
+            q, E = p.Popfirst()
+        } else {
+            q, E = p.Poplast()
+        }
+        if E != nil {
+            return pushError(E, fn + ": evict")
+        }
+        if q == nil {
+            break
+        }
+        p.n_len -= 1
+        p.len_mod = p.modcount
+        if p.on_evict != nil {
+            p.on_evict(q)
+        }
+    }
+    return nil
+}   // End of function List_base::trim.
@@ -0,0 +1,198 @@
+// src/go/s2list_merge.go   2026-10-17
+// K-way merging of sorted s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+NewMergeIter
+MergeIter::
+MergeIter::Next
+MergeIter::pull
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+merge_heap::Len
+merge_heap::Less
+merge_heap::Swap
+merge_heap::Push
+merge_heap::Pop
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "container/heap"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A MergeIter delivers the values of several sorted lists in global sorted order,
+without modifying the lists. The lists must each be sorted according to the
+same less-function. Equal values are delivered in the order of the lists in the
+argument list of NewMergeIter(), so the merge is stable.
+Like a List_iter, a MergeIter returns ErrConcurrentModification if one of the
+lists is modified during the merge.
+*/
+type MergeIter struct {
+    //----------------------//
+    //      MergeIter::     //
+    //----------------------//
+    less    func(a, b interface{}) bool // The ordering of the values.
+    iters   []List_iter                 // One iterator per list.
+    heap    merge_heap                  // Heads of the non-exhausted lists.
+    started bool                        // The heap has been filled.
+}
+
+/*
+A merge_head is the current head value of one of the lists of a MergeIter.
+*/
+type merge_head struct {
+    value interface{} // The value of the head node.
+    src   int         // The index of the list.
+}
+
+/*
+A merge_heap implements heap.Interface for the heads of a MergeIter.
+*/
+type merge_heap struct {
+    heads []merge_head
+    less  func(a, b interface{}) bool
+}
+
+/*
+NewMergeIter() returns an iterator which merges the values of the given sorted
+lists. Nil lists are treated as empty lists. If less is nil, the first
+MergeIter::Next() call picks the Less function which was registered for the
+type of the first head value, and fails if that type has none.
+*/
+func NewMergeIter(less func(a, b interface{}) bool, bases ...*List_base) *MergeIter {
+    //----------------------//
+    //     NewMergeIter     //
+    //----------------------//
+    m := new(MergeIter)
+    m.less = less
+    m.heap.less = less
+    m.iters = make([]List_iter, len(bases))
+    for i, b := range bases {
+        m.iters[i].Init(b)
+    }
+    return m
+}   // End of function NewMergeIter.
+
+/*
+MergeIter::Next() returns the next value in the merged order. The boolean return
+value is false when all lists are exhausted.
+*/
+func (p *MergeIter) Next() (interface{}, bool, error) {
+    //----------------------//
+    //    MergeIter::Next   //
+    //----------------------//
+    if p == nil {
+        return nil, false, newError(ErrNilReceiver, "MergeIter::Next: p == nil")
+    }
+    if !p.started {
+        p.started = true
+        for i := range p.iters {
+            q, E := p.pull(i)
+            if E != nil {
+                return nil, false, E
+            }
+            if q != nil {
+                p.heap.heads = append(p.heap.heads, merge_head{value: q.value, src: i})
+            }
+        }
+        if p.less == nil && len(p.heap.heads) > 0 {
+            less, E := resolveLess("MergeIter::Next", nil, p.heap.heads[0].value)
+            if E != nil {
+                return nil, false, E
+            }
+            p.less = less
+            p.heap.less = less
+        }
+        heap.Init(&p.heap)
+    }
+    if len(p.heap.heads) == 0 {
+        return nil, false, nil
+    }
+    // Replace the smallest head by its successor in the same list.
+    head := p.heap.heads[0]
+    q, E := p.pull(head.src)
+    if E != nil {
+        return nil, false, E
+    }
+    if q == nil {
+        heap.Pop(&p.heap)
+    } else {
+        p.heap.heads[0].value = q.value
+        heap.Fix(&p.heap, 0)
+    }
+    return head.value, true, nil
+}   // End of function MergeIter::Next.
+
+/*
+MergeIter::pull() is a private member function for internal use in this
+package.
+It returns the next node of list i, or nil if the list is exhausted.
+*/
+func (p *MergeIter) pull(i int) (*List_node, error) {
+    //----------------------//
+    //    MergeIter::pull   //
+    //----------------------//
+    if p.iters[i].base == nil {
+        return nil, nil
+    }
+    q, E := p.iters[i].Next()
+    if E == ErrConcurrentModification {
+        return nil, E
+    }
+    if E != nil {
+        return nil, pushError(E, "MergeIter::pull: p.iters[i].Next()")
+    }
+    return q, nil
+}   // End of function MergeIter::pull.
+
+/*
+The merge_heap methods implement heap.Interface. Ties are broken by the index of
+the list, to keep the merge stable.
+*/
+func (h *merge_heap) Len() int {
+    //----------------------//
+    //    merge_heap::Len   //
+    //----------------------//
+    return len(h.heads)
+}   // End of function merge_heap::Len.
+
+func (h *merge_heap) Less(i, j int) bool {
+    //----------------------//
+    //   merge_heap::Less   //
+    //----------------------//
+    a, b := h.heads[i], h.heads[j]
+    if h.less(a.value, b.value) {
+        return true
+    }
+    if h.less(b.value, a.value) {
+        return false
+    }
+    return a.src < b.src
+}   // End of function merge_heap::Less.
+
+func (h *merge_heap) Swap(i, j int) {
+    //----------------------//
+    //   merge_heap::Swap   //
+    //----------------------//
+    h.heads[i], h.heads[j] = h.heads[j], h.heads[i]
+}   // End of function merge_heap::Swap.
+
+func (h *merge_heap) Push(x interface{}) {
+    //----------------------//
+    //   merge_heap::Push   //
+    //----------------------//
+    h.heads = append(h.heads, x.(merge_head))
+}   // End of function merge_heap::Push.
+
+func (h *merge_heap) Pop() interface{} {
+    //----------------------//
+    //    merge_heap::Pop   //
+    //----------------------//
+    n := len(h.heads)
+    x := h.heads[n-1]
+    h.heads = h.heads[:n-1]
+    return x
+}   // End of function merge_heap::Pop.
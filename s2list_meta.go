@@ -0,0 +1,219 @@
+// src/go/s2list_meta.go   2026-10-17
+// Optional per-node metadata for s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::EnableMeta
+List_base::DisableMeta
+List_base::stamp
+List_base::popped
+List_base::SequenceRange
+List_base::SequenceGaps
+List_node::Meta
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Node_meta holds the metadata which a list with metadata enabled attaches to
+each node when the node is inserted.
+    Inserted time.Time // When the node was inserted into the list.
+    Seq      uint64    // Sequence number of the insertion, starting at 1.
+    Origin   string    // The origin tag of the list.
+    Attempts int       // Number of List_base::RequeueToBack() calls.
+The metadata stays with the node when it is removed from the list, so that it
+can be inspected after a pop. It is replaced when the node is inserted into a
+list with metadata enabled again. Moving a node within a list does not change
+its metadata.
+*/
+type Node_meta struct {
+    Inserted time.Time // When the node was inserted into the list.
+    Seq      uint64    // Sequence number of the insertion, starting at 1.
+    Origin   string    // The origin tag of the list.
+    Attempts int       // Number of List_base::RequeueToBack() calls.
+}
+
+/*
+A Seq_range is an inclusive range of metadata sequence numbers.
+    Lo uint64 // The first sequence number in the range.
+    Hi uint64 // The last sequence number in the range.
+*/
+type Seq_range struct {
+    Lo uint64 // The first sequence number in the range.
+    Hi uint64 // The last sequence number in the range.
+}
+
+/*
+List_base::EnableMeta() switches on the automatic metadata of nodes which are
+inserted into the list from now on. The origin tag is recorded in the metadata
+of every node, for auditing. Nodes which are moved in from other lists, such as
+by List_base::TransferAllTo(), are stamped too, like appended nodes. Nodes
+which are already in the list are not stamped.
+*/
+func (p *List_base) EnableMeta(origin string) error {
+    //--------------------------//
+    //  List_base::EnableMeta   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::EnableMeta: p == nil")
+    }
+    p.meta_on = true
+    p.meta_origin = origin
+    return nil
+}   // End of function List_base::EnableMeta.
+
+/*
+List_base::DisableMeta() switches off the automatic metadata. Nodes keep the
+metadata which they already have. The sequence numbering continues where it
+stopped if metadata is enabled again.
+*/
+func (p *List_base) DisableMeta() error {
+    //--------------------------//
+    //  List_base::DisableMeta  //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::DisableMeta: p == nil")
+    }
+    p.meta_on = false
+    return nil
+}   // End of function List_base::DisableMeta.
+
+/*
+List_base::stamp() is a private member function for internal use in this
+package.
+It attaches fresh metadata to a node which is being inserted into the list.
+*/
+func (p *List_base) stamp(q *List_node) {
+    //----------------------//
+    //   List_base::stamp   //
+    //----------------------//
+    p.meta_seq += 1
+    q.meta = &Node_meta{Inserted: time.Now(), Seq: p.meta_seq, Origin: p.meta_origin}
+}   // End of function List_base::stamp.
+
+/*
+List_node::Meta() returns a copy of the metadata of the node. The boolean return
+value is false if the node has no metadata.
+*/
+func (p *List_node) Meta() (Node_meta, bool) {
+    //----------------------//
+    //    List_node::Meta   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_node::Meta")
+    }
+    if p == nil || p.meta == nil {
+        return Node_meta{}, false
+    }
+    return *p.meta, true
+}   // End of function List_node::Meta.
+
+/*
+List_base::popped() is a private member function for internal use in this
+package.
+It is called by List_base::Popfirst() and List_base::Poplast() for a popped
+node while metadata is enabled. The dequeue latency of the node is counted in
+the histogram of List_base::AgeStats(). The sequence number of the node is
+checked against the highest sequence number which was popped before. Skipped
+numbers are recorded as a gap, and a number which arrives late is removed from
+its gap.
+*/
+func (p *List_base) popped(q *List_node) {
+    //----------------------//
+    //  List_base::popped   //
+    //----------------------//
+    if q.meta == nil {
+        return
+    }
+    p.latency(time.Since(q.meta.Inserted))
+    if q.meta.Seq == 0 {
+        return
+    }
+    seq := q.meta.Seq
+    if seq > p.meta_popped {
+        if seq > p.meta_popped+1 {
+            p.meta_gaps = append(p.meta_gaps, Seq_range{p.meta_popped + 1, seq - 1})
+        }
+        p.meta_popped = seq
+        return
+    }
+    // A late arrival. Split the gap which contains it.
+    for i, g := range p.meta_gaps {
+        if seq < g.Lo || seq > g.Hi {
+            continue
+        }
+        switch {
+        case g.Lo == g.Hi:
+            p.meta_gaps = append(p.meta_gaps[:i], p.meta_gaps[i+1:]...)
+        case seq == g.Lo:
+            p.meta_gaps[i].Lo += 1
+        case seq == g.Hi:
+            p.meta_gaps[i].Hi -= 1
+        default:
+            p.meta_gaps = append(p.meta_gaps[:i+1], p.meta_gaps[i:]...)
+            p.meta_gaps[i].Hi = seq - 1
+            p.meta_gaps[i+1].Lo = seq + 1
+        }
+        return
+    }
+}   // End of function List_base::popped.
+
+/*
+List_base::SequenceRange() returns the lowest and highest metadata sequence
+numbers of the nodes which are currently in the list. The boolean return value
+is false if no node in the list has metadata.
+*/
+func (p *List_base) SequenceRange() (uint64, uint64, bool) {
+    //------------------------------//
+    //   List_base::SequenceRange   //
+    //------------------------------//
+    if p == nil {
+        nilReceiver("List_base::SequenceRange")
+        return 0, 0, false
+    }
+    var lo, hi uint64
+    var found bool = false
+    for q := p.first; q != nil; q = q.next {
+        if q.meta == nil || q.meta.Seq == 0 {
+            continue
+        }
+        seq := q.meta.Seq
+        if !found || seq < lo {
+            lo = seq
+        }
+        if !found || seq > hi {
+            hi = seq
+        }
+        found = true
+    }
+    return lo, hi, found
+}   // End of function List_base::SequenceRange.
+
+/*
+List_base::SequenceGaps() returns the ranges of sequence numbers below the
+highest popped sequence number which have not been delivered by
+List_base::Popfirst() or List_base::Poplast() while metadata was enabled. A
+consumer which pops every node exactly once sees no gaps once the list is
+empty. Nodes which were removed by other methods, for example
+List_base::Remove(), leave gaps. The ranges are in the order in which they
+were detected.
+*/
+func (p *List_base) SequenceGaps() []Seq_range {
+    //------------------------------//
+    //   List_base::SequenceGaps    //
+    //------------------------------//
+    if p == nil {
+        nilReceiver("List_base::SequenceGaps")
+    }
+    if p == nil || len(p.meta_gaps) == 0 {
+        return nil
+    }
+    gaps := make([]Seq_range, len(p.meta_gaps))
+    copy(gaps, p.meta_gaps)
+    return gaps
+}   // End of function List_base::SequenceGaps.
@@ -0,0 +1,257 @@
+// src/go/s2list_must.go   2026-10-17
+// Fail-fast variants of s2list methods which panic instead of returning errors.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_node::MustGetNext
+List_node::MustGetValue
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+List_base::MustAppend
+List_base::MustAppendValue
+List_base::MustPrepend
+List_base::MustPrependValue
+List_base::MustPopfirst
+List_base::MustPoplast
+List_base::MustPopfirstValue
+List_base::MustPoplastValue
+List_base::MustRemove
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+List_iter::MustNext
+List_iter::MustPeek
+List_iter::MustRemoveCurrent
+List_iter::MustInsertAfterCurrent
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+The Must methods are for callers who regard every error of a list operation as
+a programming error, such as a nil receiver or a corrupt list. Each of them
+calls the corresponding method and panics with its error, which is an *S2Error
+or ErrConcurrentModification, so that a recover() can still inspect it with
+errors.Is() and errors.As(). Normal results, such as a nil node at the end of
+a list or when popping from an empty list, do not cause a panic.
+*/
+
+/*
+List_node::MustGetNext() is like List_node::GetNext(), but it returns only the
+node and panics if an error is returned.
+*/
+func (p *List_node) MustGetNext() *List_node {
+    //--------------------------//
+    //  List_node::MustGetNext  //
+    //--------------------------//
+    q, E := p.GetNext()
+    if E != nil {
+        panic(E)
+    }
+    return q
+}   // End of function List_node::MustGetNext.
+
+/*
+List_node::MustGetValue() is like List_node::GetValue(), but it returns only the
+value and panics if an error is returned.
+*/
+func (p *List_node) MustGetValue() interface{} {
+    //------------------------------//
+    //   List_node::MustGetValue    //
+    //------------------------------//
+    v, E := p.GetValue()
+    if E != nil {
+        panic(E)
+    }
+    return v
+}   // End of function List_node::MustGetValue.
+
+/*
+List_base::MustAppend() is like List_base::Append(), but it panics if an error
+is returned.
+*/
+func (p *List_base) MustAppend(pnode *List_node) {
+    //--------------------------//
+    //  List_base::MustAppend   //
+    //--------------------------//
+    E := p.Append(pnode)
+    if E != nil {
+        panic(E)
+    }
+}   // End of function List_base::MustAppend.
+
+/*
+List_base::MustAppendValue() is like List_base::AppendValue(), but it panics if
+an error is returned.
+*/
+func (p *List_base) MustAppendValue(v interface{}) {
+    //------------------------------//
+    //  List_base::MustAppendValue  //
+    //------------------------------//
+    E := p.AppendValue(v)
+    if E != nil {
+        panic(E)
+    }
+}   // End of function List_base::MustAppendValue.
+
+/*
+List_base::MustPrepend() is like List_base::Prepend(), but it panics if an error
+is returned.
+*/
+func (p *List_base) MustPrepend(pnode *List_node) {
+    //--------------------------//
+    //  List_base::MustPrepend  //
+    //--------------------------//
+    E := p.Prepend(pnode)
+    if E != nil {
+        panic(E)
+    }
+}   // End of function List_base::MustPrepend.
+
+/*
+List_base::MustPrependValue() is like List_base::PrependValue(), but it panics
+if an error is returned.
+*/
+func (p *List_base) MustPrependValue(v interface{}) {
+    //----------------------------------//
+    //   List_base::MustPrependValue    //
+    //----------------------------------//
+    E := p.PrependValue(v)
+    if E != nil {
+        panic(E)
+    }
+}   // End of function List_base::MustPrependValue.
+
+/*
+List_base::MustPopfirst() is like List_base::Popfirst(), but it returns only the
+node and panics if an error is returned.
+*/
+func (p *List_base) MustPopfirst() *List_node {
+    //------------------------------//
+    //   List_base::MustPopfirst    //
+    //------------------------------//
+    q, E := p.Popfirst()
+    if E != nil {
+        panic(E)
+    }
+    return q
+}   // End of function List_base::MustPopfirst.
+
+/*
+List_base::MustPoplast() is like List_base::Poplast(), but it returns only the
+node and panics if an error is returned.
+*/
+func (p *List_base) MustPoplast() *List_node {
+    //--------------------------//
+    //  List_base::MustPoplast  //
+    //--------------------------//
+    q, E := p.Poplast()
+    if E != nil {
+        panic(E)
+    }
+    return q
+}   // End of function List_base::MustPoplast.
+
+/*
+List_base::MustPopfirstValue() is like List_base::PopfirstValue(), but it
+returns only the value and the flag and panics if an error is returned.
+*/
+func (p *List_base) MustPopfirstValue() (interface{}, bool) {
+    //----------------------------------//
+    //   List_base::MustPopfirstValue   //
+    //----------------------------------//
+    v, ok, E := p.PopfirstValue()
+    if E != nil {
+        panic(E)
+    }
+    return v, ok
+}   // End of function List_base::MustPopfirstValue.
+
+/*
+List_base::MustPoplastValue() is like List_base::PoplastValue(), but it returns
+only the value and the flag and panics if an error is returned.
+*/
+func (p *List_base) MustPoplastValue() (interface{}, bool) {
+    //----------------------------------//
+    //   List_base::MustPoplastValue    //
+    //----------------------------------//
+    v, ok, E := p.PoplastValue()
+    if E != nil {
+        panic(E)
+    }
+    return v, ok
+}   // End of function List_base::MustPoplastValue.
+
+/*
+List_base::MustRemove() is like List_base::Remove(), but it returns only the
+node and panics if an error is returned.
+*/
+func (p *List_base) MustRemove(q *List_node) *List_node {
+    //--------------------------//
+    //  List_base::MustRemove   //
+    //--------------------------//
+    q, E := p.Remove(q)
+    if E != nil {
+        panic(E)
+    }
+    return q
+}   // End of function List_base::MustRemove.
+
+/*
+List_iter::MustNext() is like List_iter::Next(), but it returns only the node
+and panics if an error is returned.
+*/
+func (p *List_iter) MustNext() *List_node {
+    //--------------------------//
+    //   List_iter::MustNext    //
+    //--------------------------//
+    q, E := p.Next()
+    if E != nil {
+        panic(E)
+    }
+    return q
+}   // End of function List_iter::MustNext.
+
+/*
+List_iter::MustPeek() is like List_iter::Peek(), but it returns only the node
+and panics if an error is returned.
+*/
+func (p *List_iter) MustPeek() *List_node {
+    //--------------------------//
+    //   List_iter::MustPeek    //
+    //--------------------------//
+    q, E := p.Peek()
+    if E != nil {
+        panic(E)
+    }
+    return q
+}   // End of function List_iter::MustPeek.
+
+/*
+List_iter::MustRemoveCurrent() is like List_iter::RemoveCurrent(), but it
+returns only the node and panics if an error is returned.
+*/
+func (p *List_iter) MustRemoveCurrent() *List_node {
+    //----------------------------------//
+    //   List_iter::MustRemoveCurrent   //
+    //----------------------------------//
+    q, E := p.RemoveCurrent()
+    if E != nil {
+        panic(E)
+    }
+    return q
+}   // End of function List_iter::MustRemoveCurrent.
+
+/*
+List_iter::MustInsertAfterCurrent() is like List_iter::InsertAfterCurrent(), but
+it panics if an error is returned.
+*/
+func (p *List_iter) MustInsertAfterCurrent(v interface{}) {
+    //--------------------------------------//
+    //  List_iter::MustInsertAfterCurrent   //
+    //--------------------------------------//
+    E := p.InsertAfterCurrent(v)
+    if E != nil {
+        panic(E)
+    }
+}   // End of function List_iter::MustInsertAfterCurrent.
@@ -0,0 +1,122 @@
+// src/go/s2list_nilsafe.go   2026-10-17
+// Documented nil-receiver behaviour and an optional strict nil mode.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+SetStrictNil
+StrictNil
+nilReceiver
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "sync/atomic"
+
+//=============================================================================
+//=============================================================================
+
+/*
+NilTolerant lists the methods which accept a nil receiver silently, and the
+results which they return for it. Every other method of the package returns an
+error of kind ErrNilReceiver for a nil receiver, except where its comment says
+otherwise. The table can be used by tests and static checks of client code.
+In strict nil mode, the methods in the table panic instead. See SetStrictNil().
+*/
+var NilTolerant = map[string]string{
+    "List_node::Pinned":         "false",
+    "List_node::Meta":           "Node_meta{}, false",
+    "List_base::Empty":          "true",
+    "List_base::GetFirst":       "nil",
+    "List_base::Length":         "0",
+    "List_base::ValidLength":    "0, 0, 0",
+    "List_base::CountNilValues": "0",
+    "List_base::ModCount":       "0",
+    "List_base::CheckLevel":     "Check_fast",
+    "List_base::GetName":        "\"\"",
+    "List_base::Reserved":       "0",
+    "List_base::SequenceRange":  "0, 0, false",
+    "List_base::Snapshot":       "nil",
+    "List_base::IsSnapshot":     "false",
+    "List_base::IsFrozen":       "false",
+    "List_base::ToContainerList": "nil",
+    "List_base::FirstValue":     "nil, false",
+    "List_base::LastValue":      "nil, false",
+    "List_base::SequenceGaps":   "nil",
+    "List_base::Watch":          "a closed channel, a no-op function",
+    "List_base::GetHooks":       "Hooks{}",
+    "List_base::Stats":          "List_stats{}",
+    "List_base::MaxLen":         "0",
+    "List_base::ElementType":    "nil",
+    "List_base::NotifyChan":     "nil",
+    "List_iter::ItemCount":      "0",
+    "List_iter::ItemCountValid": "0, 0, 0",
+    "List_chain::Base":          "nil",
+    "List_chain::Length":        "0",
+    "Cursor::Node":              "nil",
+    "Cursor::Valid":             "false",
+    "B_list::Length":            "0",
+    "Rope::Len":                 "0",
+    "Rope::Bytes":               "nil",
+    "FrameBuffer::Len":          "0",
+    "ExpiringList::Len":         "0",
+    "DelayQueue::Len":           "0",
+    "SyncList::Len":             "0",
+    "DurableQueue::Len":         "0",
+    "WSDeque::Len":              "0",
+    "ByteChain::Len":            "0",
+    "ByteChain::Bytes":          "nil",
+    "Hook::Owner":               "nil",
+    "Hook::Node":                "nil",
+    "Hook::List":                "nil",
+    "Hook::Linked":              "false",
+    "Builder::Add":              "nil",
+}
+
+/*
+strict_nil is non-zero in strict nil mode. See SetStrictNil().
+*/
+var strict_nil int32 = 0
+
+/*
+SetStrictNil() switches strict nil mode on or off for the whole package. In
+strict nil mode, the methods which are listed in NilTolerant panic with an
+error of kind ErrNilReceiver for a nil receiver, instead of silently returning
+a default such as a zero length. Since these methods have no error results,
+this turns a silent nil, which hides a bug, into a failure at the call. It is
+intended for tests and debugging. The mode is off by default.
+*/
+func SetStrictNil(on bool) {
+    //----------------------//
+    //     SetStrictNil     //
+    //----------------------//
+    if on {
+        atomic.StoreInt32(&strict_nil, 1)
+    } else {
+        atomic.StoreInt32(&strict_nil, 0)
+    }
+}   // End of function SetStrictNil.
+
+/*
+StrictNil() returns true in strict nil mode.
+*/
+func StrictNil() bool {
+    //----------------------//
+    //       StrictNil      //
+    //----------------------//
+    return atomic.LoadInt32(&strict_nil) != 0
+}   // End of function StrictNil.
+
+/*
+nilReceiver() is a private function for internal use in this package.
+It is called by the methods in NilTolerant when the receiver is nil. In strict
+nil mode, it panics with an error of kind ErrNilReceiver. The argument is the
+name of the calling method.
+*/
+func nilReceiver(fn string) {
+    //----------------------//
+    //      nilReceiver     //
+    //----------------------//
+    if atomic.LoadInt32(&strict_nil) != 0 {
+        panic(newError(ErrNilReceiver, fn + ": p == nil"))
+    }
+}   // End of function nilReceiver.
@@ -0,0 +1,199 @@
+// src/go/s2list_options.go   2026-10-17
+// Constructors of s2list lists with functional options.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+WithName
+WithMaxLen
+WithCheckLevel
+WithElementType
+WithValidator
+WithHooks
+New
+NewFromSlice
+NewFromChan
+List_base::apply
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "reflect"
+
+//=============================================================================
+//=============================================================================
+
+/*
+An Option configures a list when it is created by New(), NewFromSlice(),
+NewFromChan() or NewSyncList(). Each option calls the corresponding setter of
+List_base, so the zero-value List_base and the setters remain equivalent.
+*/
+type Option func(p *List_base) error
+
+/*
+WithName() returns an option which calls List_base::SetName().
+*/
+func WithName(name string) Option {
+    //----------------------//
+    //       WithName       //
+    //----------------------//
+    return func(p *List_base) error {
+        return p.SetName(name)
+    }
+}   // End of function WithName.
+
+/*
+WithMaxLen() returns an option which calls List_base::SetMaxLen().
+*/
+func WithMaxLen(n int, onEvict func(*List_node)) Option {
+    //----------------------//
+    //      WithMaxLen      //
+    //----------------------//
+    return func(p *List_base) error {
+        return p.SetMaxLen(n, onEvict)
+    }
+}   // End of function WithMaxLen.
+
+/*
+WithCheckLevel() returns an option which calls List_base::SetCheckLevel().
+*/
+func WithCheckLevel(level Check_level) Option {
+    //----------------------//
+    //    WithCheckLevel    //
+    //----------------------//
+    return func(p *List_base) error {
+        return p.SetCheckLevel(level)
+    }
+}   // End of function WithCheckLevel.
+
+/*
+WithElementType() returns an option which calls List_base::SetElementType().
+*/
+func WithElementType(t reflect.Type) Option {
+    //----------------------//
+    //    WithElementType   //
+    //----------------------//
+    return func(p *List_base) error {
+        return p.SetElementType(t)
+    }
+}   // End of function WithElementType.
+
+/*
+WithValidator() returns an option which calls List_base::SetValidator().
+*/
+func WithValidator(validate func(v interface{}) error) Option {
+    //----------------------//
+    //     WithValidator    //
+    //----------------------//
+    return func(p *List_base) error {
+        return p.SetValidator(validate)
+    }
+}   // End of function WithValidator.
+
+/*
+WithHooks() returns an option which calls List_base::SetHooks().
+*/
+func WithHooks(h Hooks) Option {
+    //----------------------//
+    //       WithHooks      //
+    //----------------------//
+    return func(p *List_base) error {
+        return p.SetHooks(h)
+    }
+}   // End of function WithHooks.
+
+/*
+New() returns an empty list which is configured by the options, in order.
+Since options are normally fixed in the program, an invalid option is a
+programming error, and New() panics with the error of the option.
+A list which is shared by goroutines is created with NewSyncList(), which takes
+the same options.
+*/
+func New(opts ...Option) *List_base {
+    //----------------------//
+    //          New         //
+    //----------------------//
+    p := new(List_base)
+    E := p.apply("New", opts)
+    if E != nil {
+        panic(E)
+    }
+    return p
+}   // End of function New.
+
+/*
+NewFromSlice() returns a list which is configured by the options, with one node
+for each of the values, in order. The values are appended with
+List_base::AppendValue(), so they are checked by the element type and the
+validator, and a length limit keeps only the last values.
+*/
+func NewFromSlice(values []interface{}, opts ...Option) (*List_base, error) {
+    //----------------------//
+    //     NewFromSlice     //
+    //----------------------//
+    p := new(List_base)
+    E := p.apply("NewFromSlice", opts)
+    if E != nil {
+        return nil, E
+    }
+    for _, v := range values {
+        E = p.AppendValue(v)
+        if E != nil {
+            return nil, pushError(E, "NewFromSlice: p.AppendValue(v)")
+        }
+    }
+    return p, nil
+}   // End of function NewFromSlice.
+
+/*
+NewFromChan() returns a list which is configured by the options, with one node
+for each value which is received from ch until ch is closed, in order. The
+values are appended like those of NewFromSlice(). If an option or an append
+fails, the remaining values are still received and discarded until ch is
+closed, so that the sender is not blocked, and then the error is returned.
+*/
+func NewFromChan(ch <-chan interface{}, opts ...Option) (*List_base, error) {
+    //----------------------//
+    //      NewFromChan     //
+    //----------------------//
+    if ch == nil {
+        return nil, newError(ErrNilArgument, "NewFromChan: ch == nil")
+    }
+    p := new(List_base)
+    E := p.apply("NewFromChan", opts)
+    if E != nil {
+        for range ch {
+        }
+        return nil, E
+    }
+    for v := range ch {
+        E = p.AppendValue(v)
+        if E != nil {
+            // Drain the channel, so that the sender can finish.
+            for range ch {
+            }
+            return nil, pushError(E, "NewFromChan: p.AppendValue(v)")
+        }
+    }
+    return p, nil
+}   // End of function NewFromChan.
+
+/*
+List_base::apply() is a private member function for internal use in this
+package.
+It applies the options to the list, in order. Nil options are skipped.
+*/
+func (p *List_base) apply(fn string, opts []Option) error {
+    //----------------------//
+    //   List_base::apply   //
+    //----------------------//
+    for _, opt := range opts {
+        if opt == nil {
+            continue
+        }
+        E := opt(p)
+        if E != nil {
+            return pushError(E, fn + ": opt(p)")
+        }
+    }
+    return nil
+}   // End of function List_base::apply.
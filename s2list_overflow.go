@@ -0,0 +1,50 @@
+// src/go/s2list_overflow.go   2026-10-17
+// Overflow policies for bounded lists and queues.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Overflow_policy::String
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+An Overflow_policy selects what a bounded list or queue does when a value is
+added while it is full. The policy is chosen when the bounded object is
+created, so that all code which adds values to it gets the same behaviour.
+    Overflow_reject         the insertion fails with an error (the default)
+    Overflow_drop_oldest    the oldest values are removed to make room
+    Overflow_drop_newest    the new value is discarded without an error
+    Overflow_block          the insertion waits until there is room
+*/
+type Overflow_policy int
+
+const (
+    Overflow_reject Overflow_policy = iota
+    Overflow_drop_oldest
+    Overflow_drop_newest
+    Overflow_block
+)
+
+/*
+Overflow_policy::String() returns the name of the policy.
+*/
+func (p Overflow_policy) String() string {
+    //------------------------------//
+    //   Overflow_policy::String    //
+    //------------------------------//
+    switch p {
+    case Overflow_reject:
+        return "reject"
+    case Overflow_drop_oldest:
+        return "drop-oldest"
+    case Overflow_drop_newest:
+        return "drop-newest"
+    case Overflow_block:
+        return "block"
+    }
+    return "unknown"
+}   // End of function Overflow_policy::String.
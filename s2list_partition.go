@@ -0,0 +1,179 @@
+// src/go/s2list_partition.go   2026-10-17
+// Partitioning and grouping of s2list lists by relinking nodes.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Partition
+List_base::GroupBy
+List_base::GroupMap
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::Partition() moves the nodes for which pred returns true into one new
+list, and the other nodes into a second new list, in a single pass. The nodes
+keep their relative order, and their base-pointers are set to their new lists.
+No nodes are copied. The receiver is empty afterwards, and cursors registered
+with it become invalid.
+*/
+func (p *List_base) Partition(pred func(interface{}) bool) (*List_base, *List_base, error) {
+    //--------------------------//
+    //   List_base::Partition   //
+    //--------------------------//
+    if p == nil {
+        return nil, nil, newError(ErrNilReceiver, "List_base::Partition: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Partition", time.Now())
+    }
+    if pred == nil {
+        return nil, nil, p.fail(ErrNilArgument, nil, "List_base::Partition: pred == nil")
+    }
+    if p.first != nil && p.last == nil {
+        return nil, nil, p.fail(ErrCorruptList, nil, "List_base::Partition: p.first != p.last == nil")
+    }
+    E := p.modify("List_base::Partition")
+    if E != nil {
+        return nil, nil, E
+    }
+    pass := new(List_base)
+    fail := new(List_base)
+    q, _ := p.takeChain()
+    for q != nil {
+        next := q.next
+        if pred(q.value) {
+            pass.putChain(q, q)
+        } else {
+            fail.putChain(q, q)
+        }
+        q = next
+    }
+    return pass, fail, nil
+}   // End of function List_base::Partition.
+
+/*
+List_base::GroupBy() moves every node into a new list for the key which the
+function key returns for its value. The keys must be comparable, because they
+are used as map keys. The nodes keep their relative order within each group,
+and their base-pointers are set to their new lists. No nodes are copied. The
+receiver is empty afterwards, and cursors registered with it become invalid.
+If a key is not comparable, an error is returned and the list is unchanged.
+*/
+func (p *List_base) GroupBy(key func(interface{}) interface{}) (map[interface{}]*List_base, error) {
+    //--------------------------//
+    //    List_base::GroupBy    //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::GroupBy: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::GroupBy", time.Now())
+    }
+    if key == nil {
+        return nil, p.fail(ErrNilArgument, nil, "List_base::GroupBy: key == nil")
+    }
+    if p.first != nil && p.last == nil {
+        return nil, p.fail(ErrCorruptList, nil, "List_base::GroupBy: p.first != p.last == nil")
+    }
+    // Compute all keys before any node is moved.
+    var keys []interface{}
+    for q := p.first; q != nil; q = q.next {
+        k := key(q.value)
+        if k != nil && !hashable(k) {
+            return nil, p.fail(ErrInvalidArgument, nil, "List_base::GroupBy: key is not comparable")
+        }
+        keys = append(keys, k)
+    }
+    E := p.modify("List_base::GroupBy")
+    if E != nil {
+        return nil, E
+    }
+    groups := make(map[interface{}]*List_base)
+    q, _ := p.takeChain()
+    for i := 0; q != nil; i += 1 {
+        next := q.next
+        g := groups[keys[i]]
+        if g == nil {
+            g = new(List_base)
+            groups[keys[i]] = g
+        }
+        g.putChain(q, q)
+        q = next
+    }
+    return groups, nil
+}   // End of function List_base::GroupBy.
+
+/*
+List_base::GroupMap() groups the nodes of the list by the string which the
+function key returns for their values, calls f for each group, and
+concatenates the lists which f returns into a new list. The groups are passed
+to f in the order of the first appearance of their keys in the list, and the
+nodes keep their relative order within each group. The function f may modify
+and return the group itself, or return another list, whose nodes are then
+moved into the result. A nil return value drops the group.
+No nodes are copied. The receiver is empty afterwards, and cursors registered
+with it become invalid.
+*/
+func (p *List_base) GroupMap(key func(interface{}) string,
+    f func(key string, group *List_base) *List_base) (*List_base, error) {
+    //--------------------------//
+    //   List_base::GroupMap    //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::GroupMap: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::GroupMap", time.Now())
+    }
+    if key == nil {
+        return nil, p.fail(ErrNilArgument, nil, "List_base::GroupMap: key == nil")
+    }
+    if f == nil {
+        return nil, p.fail(ErrNilArgument, nil, "List_base::GroupMap: f == nil")
+    }
+    if p.first != nil && p.last == nil {
+        return nil, p.fail(ErrCorruptList, nil, "List_base::GroupMap: p.first != p.last == nil")
+    }
+    E := p.modify("List_base::GroupMap")
+    if E != nil {
+        return nil, E
+    }
+    var order []string
+    groups := make(map[string]*List_base)
+    q, _ := p.takeChain()
+    for q != nil {
+        next := q.next
+        k := key(q.value)
+        g := groups[k]
+        if g == nil {
+            g = new(List_base)
+            groups[k] = g
+            order = append(order, k)
+        }
+        g.putChain(q, q)
+        q = next
+    }
+    out := new(List_base)
+    for _, k := range order {
+        r := f(k, groups[k])
+        if r == nil || r.first == nil {
+            continue
+        }
+        if r == out {
+            return out, p.fail(ErrInvalidArgument, nil, "List_base::GroupMap: f returned the result list")
+        }
+        E = r.modify("List_base::GroupMap")
+        if E != nil {
+            return out, pushError(E, "List_base::GroupMap: r.modify()")
+        }
+        first, last := r.takeChain()
+        out.putChain(first, last)
+    }
+    return out, nil
+}   // End of function List_base::GroupMap.
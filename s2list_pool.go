@@ -0,0 +1,109 @@
+// src/go/s2list_pool.go   2026-10-17
+// Pre-allocation of s2list nodes.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Reserve
+List_base::Reserved
+List_base::newNode
+List_base::putBack
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "errors"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::Reserve() makes sure that at least n nodes are pre-allocated for the
+list. The nodes are allocated in a single contiguous block, and they are used by
+the methods which create nodes for values, like List_base::AppendValue(). This
+smooths out allocation latency before a known bulk insertion.
+Nodes which are later removed from the list are not returned to the pool. They
+belong to the caller, like any other removed node. Only a node whose insertion
+fails, such as for a value of the wrong type, goes back to the pool.
+*/
+func (p *List_base) Reserve(n int) error {
+    //----------------------//
+    //  List_base::Reserve  //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::Reserve: p == nil")
+    }
+    if n < 0 {
+        return p.fail(ErrInvalidArgument, nil, "List_base::Reserve: n < 0")
+    }
+    n -= len(p.pool)
+    if n <= 0 {
+        return nil
+    }
+    slab := make([]List_node, n)
+    for i := range slab {
+        p.pool = append(p.pool, &slab[i])
+    }
+    return nil
+}   // End of function List_base::Reserve.
+
+/*
+List_base::Reserved() returns the number of pre-allocated nodes which have not
+been used yet.
+*/
+func (p *List_base) Reserved() int {
+    //--------------------------//
+    //   List_base::Reserved    //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("List_base::Reserved")
+        return 0
+    }
+    return len(p.pool)
+}   // End of function List_base::Reserved.
+
+/*
+List_base::newNode() is a private member function for internal use in this
+package.
+It returns an unlinked node with the given value, taken from the pool of
+pre-allocated nodes if possible.
+*/
+func (p *List_base) newNode(v interface{}) *List_node {
+    //----------------------//
+    //  List_base::newNode  //
+    //----------------------//
+    var pnode *List_node
+    if n := len(p.pool); n > 0 {
+        pnode = p.pool[n-1]
+        p.pool[n-1] = nil
+        p.pool = p.pool[:n-1]
+    } else {
+        pnode = new(List_node)
+    }
+    pnode.value = v
+    return pnode
+}   // End of function List_base::newNode.
+
+/*
+List_base::putBack() is a private member function for internal use in this
+package.
+It returns the node pnode from List_base::newNode() to the pool after the
+insertion of pnode failed with the error E, which is returned. The node was
+never seen by the caller, so it is also removed from E. A node which was
+inserted before the failure, such as by an append whose eviction failed, stays
+in the list.
+*/
+func (p *List_base) putBack(pnode *List_node, E error) error {
+    //----------------------//
+    //  List_base::putBack  //
+    //----------------------//
+    if pnode.base != nil {
+        return E
+    }
+    var k *S2Error
+    if errors.As(E, &k) && k.Node == pnode {
+        k.Node = nil
+    }
+    *pnode = List_node{}
+    p.pool = append(p.pool, pnode)
+    return E
+}   // End of function List_base::putBack.
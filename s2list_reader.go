@@ -0,0 +1,140 @@
+// src/go/s2list_reader.go   2026-10-17
+// An io.Reader over s2list lists of byte slices.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+list_reader::
+List_base::Reader
+list_reader::Read
+list_reader::WriteTo
+list_reader::chunk
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "io"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A list_reader reads the concatenation of the []byte values of a list.
+    it   List_iter // The iterator over the chunks.
+    rest []byte    // The unread part of the current chunk.
+    err  error     // The error which ended the reading, if any.
+*/
+type list_reader struct {
+    //--------------------------//
+    //      list_reader::       //
+    //--------------------------//
+    it   List_iter // The iterator over the chunks.
+    rest []byte    // The unread part of the current chunk.
+    err  error     // The error which ended the reading, if any.
+}
+
+/*
+List_base::Reader() returns an io.Reader which reads the concatenation of the
+values of the list, which must be of type []byte. The chunks are read on
+demand, without copying them into one buffer. Nil values and empty chunks are
+skipped. A value of any other type ends the reading with an error.
+The reader also implements io.WriterTo, which writes each chunk directly to the
+writer, so that io.Copy() to a network connection does not copy the chunks.
+The list must not be modified while it is read. A modification causes
+ErrConcurrentModification. To read a list which is being modified, read a
+snapshot. See List_base::Snapshot().
+*/
+func (p *List_base) Reader() io.Reader {
+    //----------------------//
+    //   List_base::Reader  //
+    //----------------------//
+    r := new(list_reader)
+    if p == nil {
+        r.err = newError(ErrNilReceiver, "List_base::Reader: p == nil")
+        return r
+    }
+    r.it.Init(p)
+    return r
+}   // End of function List_base::Reader.
+
+/*
+list_reader::Read() reads up to len(b) bytes into b, as io.Reader requires.
+*/
+func (p *list_reader) Read(b []byte) (int, error) {
+    //----------------------//
+    //   list_reader::Read  //
+    //----------------------//
+    var n int = 0
+    for n < len(b) {
+        if len(p.rest) == 0 {
+            if !p.chunk() {
+                break
+            }
+        }
+        k := copy(b[n:], p.rest)
+        p.rest = p.rest[k:]
+        n += k
+    }
+    if n > 0 {
+        return n, nil
+    }
+    if len(b) == 0 && p.err == nil {
+        return 0, nil
+    }
+    return 0, p.err
+}   // End of function list_reader::Read.
+
+/*
+list_reader::WriteTo() writes the unread chunks to w, as io.WriterTo requires.
+*/
+func (p *list_reader) WriteTo(w io.Writer) (int64, error) {
+    //--------------------------//
+    //   list_reader::WriteTo   //
+    //--------------------------//
+    var n int64 = 0
+    for len(p.rest) > 0 || p.chunk() {
+        k, E := w.Write(p.rest)
+        p.rest = p.rest[k:]
+        n += int64(k)
+        if E != nil {
+            return n, E
+        }
+    }
+    if p.err == io.EOF {
+        return n, nil
+    }
+    return n, p.err
+}   // End of function list_reader::WriteTo.
+
+/*
+list_reader::chunk() advances to the next non-empty chunk. The return value is
+false if there is no such chunk. Then p.err is io.EOF or the error.
+*/
+func (p *list_reader) chunk() bool {
+    //----------------------//
+    //  list_reader::chunk  //
+    //----------------------//
+    for p.err == nil {
+        q, E := p.it.Next()
+        if E != nil {
+            p.err = pushError(E, "list_reader::chunk: p.it.Next()")
+            break
+        }
+        if q == nil {
+            p.err = io.EOF
+            break
+        }
+        if q.value == nil {
+            continue
+        }
+        b, ok := q.value.([]byte)
+        if !ok {
+            p.err = p.it.base.fail(ErrInvalidArgument, q, "list_reader::chunk: value is not []byte")
+            break
+        }
+        if len(b) > 0 {
+            p.rest = b
+            return true
+        }
+    }
+    return false
+}   // End of function list_reader::chunk.
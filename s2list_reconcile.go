@@ -0,0 +1,115 @@
+// src/go/s2list_reconcile.go   2026-10-17
+// Reconciliation of divergent replicas of keyed s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Reconcile
+majorityPick
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+Reconcile() merges divergent replicas of a keyed list into a new list which
+all replicas can agree on. The function key returns the key of a value. For
+each key, the values of the first node with that key in each replica are
+collected as the candidates, in the order of the replicas, and the function
+pick chooses the value of the key in the result. If pick returns nil, the key
+is dropped. Since the candidates contain one value for each replica which holds
+the key, pick can compare len(candidates) with len(lists) to apply a quorum.
+If pick is nil, the value which occurs most often among the candidates is
+chosen if it occurs in more than half of the replicas, and the key is dropped
+otherwise. The values are compared as by Equal() with a nil eq.
+The keys are ordered as in the first replica. Keys which are not in the first
+replica follow in the order in which they are first found in the later
+replicas. A nil replica is treated as an empty list. The replicas are not
+modified.
+*/
+func Reconcile(lists []*List_base, key func(interface{}) string,
+    pick func(candidates []interface{}) interface{}) (*List_base, error) {
+    //----------------------//
+    //       Reconcile      //
+    //----------------------//
+    if key == nil {
+        return nil, newError(ErrNilArgument, "Reconcile: key == nil")
+    }
+    if pick == nil {
+        quorum := len(lists) / 2 + 1
+        pick = func(candidates []interface{}) interface{} {
+            return majorityPick(candidates, quorum)
+        }
+    }
+    var order []string
+    candidates := make(map[string][]interface{})
+    for _, l := range lists {
+        if l == nil {
+            continue
+        }
+        seen := make(map[string]bool)
+        var it List_iter
+        it.Init(l)
+        for {
+            q, E := it.Next()
+            if E != nil {
+                return nil, pushError(E, "Reconcile: it.Next()")
+            }
+            if q == nil {
+                break
+            }
+            k := key(q.value)
+            if seen[k] {
+                continue
+            }
+            seen[k] = true
+            if _, ok := candidates[k]; !ok {
+                order = append(order, k)
+            }
+            candidates[k] = append(candidates[k], q.value)
+        }
+    }
+    r := new(List_base)
+    for _, k := range order {
+        v := pick(candidates[k])
+        if v == nil {
+            continue
+        }
+        E := r.AppendValue(v)
+        if E != nil {
+            return nil, pushError(E, "Reconcile: r.AppendValue(v)")
+        }
+    }
+    return r, nil
+}   // End of function Reconcile.
+
+/*
+majorityPick() is a private function for internal use in this package.
+It returns the value which occurs most often among the candidates if it occurs
+at least quorum times, or else nil. Of two equally frequent values, the first
+is returned.
+*/
+func majorityPick(candidates []interface{}, quorum int) interface{} {
+    //----------------------//
+    //     majorityPick     //
+    //----------------------//
+    var best interface{}
+    var n_best int = 0
+    for i, v := range candidates {
+        var n int = 0
+        for _, w := range candidates[i:] {
+            if valuesEqual(v, w) {
+                n += 1
+            }
+        }
+        if n > n_best {
+            best = v
+            n_best = n
+        }
+    }
+    if n_best < quorum {
+        return nil
+    }
+    return best
+}   // End of function majorityPick.
@@ -0,0 +1,137 @@
+// src/go/s2list_registry.go   2026-10-17
+// Registry of comparison functions per value type.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+RegisterComparator
+LookupComparator
+valuesEqual
+tryEqual
+resolveLess
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "reflect"
+import "sync"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Comparator holds the comparison functions for values of one type. Any of the
+functions may be nil.
+    Equal func(a, b interface{}) bool // Equality of two values.
+    Less  func(a, b interface{}) bool // Strict ordering of two values.
+    Hash  func(v interface{}) uint64  // Hash consistent with Equal.
+Methods which take a comparison function, like List_base::ContainsValue(),
+List_base::Unique(), Compare() and List_base::SortExternal(), fall back to the
+registered function for the type of the values if they are passed nil.
+*/
+type Comparator struct {
+    Equal func(a, b interface{}) bool // Equality of two values.
+    Less  func(a, b interface{}) bool // Strict ordering of two values.
+    Hash  func(v interface{}) uint64  // Hash consistent with Equal.
+}
+
+/*
+The registered comparators, by the dynamic type of the values.
+*/
+var registry_mu sync.RWMutex
+var registry = make(map[reflect.Type]Comparator)
+
+/*
+RegisterComparator() registers the comparison functions for values of the
+dynamic type of sample. A later registration for the same type replaces the
+earlier one. This is normally called once from an init function.
+*/
+func RegisterComparator(sample interface{}, c Comparator) error {
+    //------------------------------//
+    //      RegisterComparator      //
+    //------------------------------//
+    if sample == nil {
+        return newError(ErrNilArgument, "RegisterComparator: sample == nil")
+    }
+    registry_mu.Lock()
+    defer registry_mu.Unlock()
+    registry[reflect.TypeOf(sample)] = c
+    return nil
+}   // End of function RegisterComparator.
+
+/*
+LookupComparator() returns the comparison functions which are registered for
+the dynamic type of v. The boolean return value is false if there are none.
+*/
+func LookupComparator(v interface{}) (Comparator, bool) {
+    //------------------------------//
+    //       LookupComparator       //
+    //------------------------------//
+    if v == nil {
+        return Comparator{}, false
+    }
+    registry_mu.RLock()
+    defer registry_mu.RUnlock()
+    c, ok := registry[reflect.TypeOf(v)]
+    return c, ok
+}   // End of function LookupComparator.
+
+/*
+valuesEqual() is a private function for internal use in this package.
+It compares two values with the registered Equal function of the type of a, or
+with the "==" operator if there is none. Values which cannot be compared, such
+as two slices without an Equal function, are unequal. See tryEqual().
+*/
+func valuesEqual(a, b interface{}) bool {
+    //----------------------//
+    //      valuesEqual     //
+    //----------------------//
+    eq, _ := tryEqual(a, b)
+    return eq
+}   // End of function valuesEqual.
+
+/*
+tryEqual() is a private function for internal use in this package.
+It compares two values like valuesEqual(). The second return value is false if
+the values cannot be compared, because they have the same type, which has no
+registered Equal function, and the "==" operator would panic on them. This
+happens with slices, maps and functions, and with structs or arrays which hold
+such values in interface fields. Values of different types are never equal,
+and can always be compared.
+*/
+func tryEqual(a, b interface{}) (bool, bool) {
+    //----------------------//
+    //       tryEqual       //
+    //----------------------//
+    c, ok := LookupComparator(a)
+    if ok && c.Equal != nil {
+        return c.Equal(a, b), true
+    }
+    if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) {
+        return a == b, true
+    }
+    if !hashable(a) || !hashable(b) {
+        return false, false
+    }
+    return a == b, true
+}   // End of function tryEqual.
+
+/*
+resolveLess() is a private function for internal use in this package.
+It returns less if it is not nil, or else the registered Less function of the
+type of the value sample. The argument fn is the name of the calling function,
+for error messages.
+*/
+func resolveLess(fn string, less func(a, b interface{}) bool,
+    sample interface{}) (func(a, b interface{}) bool, error) {
+    //----------------------//
+    //      resolveLess     //
+    //----------------------//
+    if less != nil {
+        return less, nil
+    }
+    c, ok := LookupComparator(sample)
+    if !ok || c.Less == nil {
+        return nil, newError(ErrNilArgument, fn + ": less == nil and no Less registered for the type")
+    }
+    return c.Less, nil
+}   // End of function resolveLess.
@@ -0,0 +1,267 @@
+// src/go/s2list_release.go   2026-10-17
+// Release callbacks for values which leave an s2list list for good.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+release_entry::
+release_state::
+List_base::SetRelease
+List_base::ReleaseExpired
+List_base::ReleasePending
+List_base::retire
+List_base::revive
+hashable
+sameValue
+release_state::forget
+release_state::sweep
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "reflect"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A release_entry records a value which has left a list and is waiting for the
+end of its grace period.
+*/
+type release_entry struct {
+    //--------------------------//
+    //     release_entry::      //
+    //--------------------------//
+    node  *List_node  // The node which left the list.
+    value interface{} // The value of the node when it left.
+    due   time.Time   // The end of the grace period.
+    done  bool        // True if the value was re-inserted or released.
+}
+
+/*
+A release_state holds the release callback of a list and the values which are
+waiting for the end of their grace periods, in order of their deadlines.
+Hashable values are also indexed by value, so that a value which is
+re-inserted in a new node is recognized. See hashable().
+*/
+type release_state struct {
+    //--------------------------//
+    //     release_state::      //
+    //--------------------------//
+    fn       func(interface{})                // The release callback.
+    grace    time.Duration                    // The grace period.
+    queue    []*release_entry                 // Waiting values, by deadline.
+    by_node  map[*List_node]*release_entry    // Waiting values, by node.
+    by_value map[interface{}][]*release_entry // Waiting hashable values.
+}
+
+/*
+List_base::SetRelease() registers a callback which is invoked for each value
+which leaves the list for good, so that external resources which are tied to
+the value, such as temporary files or buffers, can be reclaimed.
+A value which is removed with List_base::Popfirst(), List_base::Poplast(),
+List_base::Remove(), List_base::Replace() or List_iter::RemoveCurrent() is
+released when it has not been inserted into the list again within the grace
+period. It counts as re-inserted if the same node is appended, prepended or
+inserted again, or if the value is hashable and an equal value is inserted.
+The values which are removed by List_base::Clear(), List_base::Truncate(),
+List_base::Drop(), List_base::Dedup() and List_base::Unique() are released at
+once, except for a duplicate which is identical to a value that stays in the
+list. Nil values are never released. Values which are moved to other lists,
+such as by List_base::TransferAllTo(), are not released, because they are
+still in use.
+Expired values are released by the next modification of the list, or by
+List_base::ReleaseExpired(). The callback must not modify the list.
+A nil callback switches the release off. Values which are still waiting are
+then forgotten.
+*/
+func (p *List_base) SetRelease(release func(v interface{}), grace time.Duration) error {
+    //--------------------------//
+    //  List_base::SetRelease   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetRelease: p == nil")
+    }
+    if grace < 0 {
+        return p.fail(ErrInvalidArgument, nil, "List_base::SetRelease: grace < 0")
+    }
+    if release == nil {
+        p.release = nil
+        return nil
+    }
+    if p.release == nil {
+        p.release = &release_state{by_node: make(map[*List_node]*release_entry),
+            by_value: make(map[interface{}][]*release_entry)}
+    }
+    p.release.fn = release
+    p.release.grace = grace
+    return nil
+}   // End of function List_base::SetRelease.
+
+/*
+List_base::ReleaseExpired() releases the values whose grace periods have ended,
+and returns the number of released values.
+*/
+func (p *List_base) ReleaseExpired() (int, error) {
+    //------------------------------//
+    //  List_base::ReleaseExpired   //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::ReleaseExpired: p == nil")
+    }
+    if p.release == nil {
+        return 0, nil
+    }
+    return p.release.sweep(time.Now(), false), nil
+}   // End of function List_base::ReleaseExpired.
+
+/*
+List_base::ReleasePending() releases all waiting values without waiting for
+the ends of their grace periods, for example when the list is shut down, and
+returns the number of released values.
+*/
+func (p *List_base) ReleasePending() (int, error) {
+    //------------------------------//
+    //  List_base::ReleasePending   //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::ReleasePending: p == nil")
+    }
+    if p.release == nil {
+        return 0, nil
+    }
+    return p.release.sweep(time.Now(), true), nil
+}   // End of function List_base::ReleasePending.
+
+/*
+List_base::retire() is a private member function for internal use in this
+package.
+It is called when the node q has been removed from the list, if a release
+callback is registered. The value of q waits for the end of the grace period,
+or it is released at once if the grace period is zero or now is true.
+*/
+func (p *List_base) retire(q *List_node, now bool) {
+    //----------------------//
+    //   List_base::retire  //
+    //----------------------//
+    r := p.release
+    if q.value == nil {
+        return
+    }
+    if now || r.grace == 0 {
+        r.fn(q.value)
+        return
+    }
+    e := &release_entry{node: q, value: q.value, due: time.Now().Add(r.grace)}
+    r.queue = append(r.queue, e)
+    r.by_node[q] = e
+    if hashable(q.value) {
+        r.by_value[q.value] = append(r.by_value[q.value], e)
+    }
+}   // End of function List_base::retire.
+
+/*
+List_base::revive() is a private member function for internal use in this
+package.
+It is called when the node q has been inserted into the list, if a release
+callback is registered. A waiting value of the same node, or else an equal
+waiting value, is no longer released.
+*/
+func (p *List_base) revive(q *List_node) {
+    //----------------------//
+    //   List_base::revive  //
+    //----------------------//
+    r := p.release
+    if len(r.by_node) == 0 {
+        return
+    }
+    e := r.by_node[q]
+    if e == nil && hashable(q.value) {
+        if list := r.by_value[q.value]; len(list) > 0 {
+            e = list[0]
+        }
+    }
+    if e != nil {
+        r.forget(e)
+    }
+}   // End of function List_base::revive.
+
+/*
+hashable() returns true if the value v is not nil and can be used as a map key
+without a panic. Unlike the comparability of the type of v, this looks at the
+dynamic values of interface fields, so that a struct with an interface field
+which holds a slice is not hashable.
+*/
+func hashable(v interface{}) bool {
+    //----------------------//
+    //       hashable       //
+    //----------------------//
+    return v != nil && reflect.ValueOf(v).Comparable()
+}   // End of function hashable.
+
+/*
+sameValue() returns true if the values a and b are hashable and equal, such as
+two copies of the same pointer.
+*/
+func sameValue(a, b interface{}) bool {
+    //----------------------//
+    //       sameValue      //
+    //----------------------//
+    return hashable(a) && hashable(b) && a == b
+}   // End of function sameValue.
+
+/*
+release_state::forget() removes the entry e from the indexes and marks it as
+done. The entry stays in the queue until it reaches the front.
+*/
+func (p *release_state) forget(e *release_entry) {
+    //--------------------------//
+    //  release_state::forget   //
+    //--------------------------//
+    e.done = true
+    if p.by_node[e.node] == e {
+        delete(p.by_node, e.node)
+    }
+    if !hashable(e.value) {
+        return
+    }
+    list := p.by_value[e.value]
+    for i, x := range list {
+        if x == e {
+            list = append(list[:i], list[i+1:]...)
+            break
+        }
+    }
+    if len(list) == 0 {
+        delete(p.by_value, e.value)
+    } else {
+        p.by_value[e.value] = list
+    }
+}   // End of function release_state::forget.
+
+/*
+release_state::sweep() releases the waiting values whose deadlines are not
+after now, or all waiting values if all is true, and returns the number of
+released values.
+*/
+func (p *release_state) sweep(now time.Time, all bool) int {
+    //--------------------------//
+    //   release_state::sweep   //
+    //--------------------------//
+    var n int = 0
+    for len(p.queue) > 0 {
+        e := p.queue[0]
+        if !e.done && !all && e.due.After(now) {
+            break
+        }
+        p.queue[0] = nil
+        p.queue = p.queue[1:]
+        if e.done {
+            continue
+        }
+        p.forget(e)
+        p.fn(e.value)
+        n += 1
+    }
+    return n
+}   // End of function release_state::sweep.
@@ -0,0 +1,546 @@
+// src/go/s2list_reorder.go   2026-10-17
+// Reordering of the nodes of s2list lists by relinking.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Rotate
+List_base::Swap
+List_base::Replace
+List_base::pred
+List_base::MoveToFront
+List_base::MoveToBack
+List_base::MoveAfter
+List_base::MoveBefore
+List_base::move
+List_base::SetAccessMode
+List_base::AccessValue
+List_base::PromoteWhere
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "sync/atomic"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+An Access_mode selects the self-organizing heuristic which
+List_base::AccessValue() applies to a node which it finds.
+    Access_static           the list is not reorganized (the default)
+    Access_move_to_front    the node is moved to the front of the list
+    Access_transpose        the node is exchanged with its predecessor
+Move-to-front adapts quickly to a skewed access distribution. Transpose adapts
+slowly, but it is more stable when the distribution changes often.
+*/
+type Access_mode int
+
+const (
+    Access_static Access_mode = iota
+    Access_move_to_front
+    Access_transpose
+)
+
+/*
+List_base::Rotate() rotates the list by k positions by relinking the nodes. For
+positive k, the list is rotated to the left, so that the node at zero-based
+position k becomes the first node. For negative k, the list is rotated to the
+right, so that the last -k nodes move to the front. The rotation is reduced
+modulo the length of the list. This costs O(n) time and O(1) space. No nodes
+are added or removed, so registered cursors stay at their nodes.
+*/
+func (p *List_base) Rotate(k int) error {
+    //----------------------//
+    //   List_base::Rotate  //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::Rotate: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Rotate", time.Now())
+    }
+    if p.first == nil {
+        return nil
+    }
+    if p.last == nil {
+        return p.fail(ErrCorruptList, nil, "List_base::Rotate: p.first != p.last == nil")
+    }
+    n := p.Length()
+    k %= n
+    if k < 0 {
+        k += n
+    }
+    if k == 0 {
+        return nil
+    }
+    E := p.modify("List_base::Rotate")
+    if E != nil {
+        return E
+    }
+    // Find the node which becomes the last node.
+    q := p.nth(k - 1)
+    // Close the ring and cut it after q.
+    head := p.first
+    p.last.next = p.first
+    p.first = q.next
+    p.last = q
+    q.next = nil
+    if p.watchers != nil {
+        // The former first node and the nodes after it have moved.
+        var moved bool = false
+        p.notifyMoves(func(r *List_node) bool {
+            moved = moved || r == head
+            return moved
+        })
+    }
+    return nil
+}   // End of function List_base::Rotate.
+
+/*
+List_base::Swap() exchanges the positions of the member nodes a and b in the
+list by relinking them. Their values are not touched. This costs O(n), because
+the predecessors of the nodes must be found.
+*/
+func (p *List_base) Swap(a, b *List_node) error {
+    //----------------------//
+    //    List_base::Swap   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::Swap: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Swap", time.Now())
+    }
+    if a == nil || b == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::Swap: a == nil || b == nil")
+    }
+    if a.base != p {
+        return p.fail(ErrNotMember, a, "List_base::Swap: a.base != p")
+    }
+    if b.base != p {
+        return p.fail(ErrNotMember, b, "List_base::Swap: b.base != p")
+    }
+    if a == b {
+        return nil
+    }
+    prev_a, E := p.pred("List_base::Swap", a)
+    if E != nil {
+        return E
+    }
+    prev_b, E := p.pred("List_base::Swap", b)
+    if E != nil {
+        return E
+    }
+    E = p.modify("List_base::Swap")
+    if E != nil {
+        return E
+    }
+    // This also works for adjacent nodes.
+    if prev_a != nil {
+        prev_a.next = b
+    } else {
+        p.first = b
+    }
+    if prev_b != nil {
+        prev_b.next = a
+    } else {
+        p.first = a
+    }
+    a.next, b.next = b.next, a.next
+    if p.last == a {
+        p.last = b
+    } else if p.last == b {
+        p.last = a
+    }
+    if p.watchers != nil {
+        p.notifyMoves(func(q *List_node) bool { return q == a || q == b })
+    }
+    return nil
+}   // End of function List_base::Swap.
+
+/*
+List_base::Replace() substitutes the node new_node, which must not be in any
+list, for the member node old_node. The new node takes over the position of the
+old node, and the old node is cast adrift. Cursors which are positioned at the
+old node move to the new node. This costs O(n), because the predecessor of the
+old node must be found.
+The value of the new node is checked like a value which is appended, and the
+exchange is reported like a removal of the old node and an insertion of the new
+node, to the release callback, the watchers, the hooks and the statistics.
+*/
+func (p *List_base) Replace(old_node, new_node *List_node) error {
+    //----------------------//
+    //  List_base::Replace  //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::Replace: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Replace", time.Now())
+    }
+    if old_node == nil || new_node == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::Replace: old_node == nil || new_node == nil")
+    }
+    if old_node.base != p {
+        return p.fail(ErrNotMember, old_node, "List_base::Replace: old_node.base != p")
+    }
+    // Can't put an object in multiple lists.
+    if new_node.base != nil {
+        return p.fail(ErrNodeInOtherList, new_node, "List_base::Replace: new_node.base != nil")
+    }
+    if p.elem_type != nil || p.validator != nil {
+        E := p.checkValue("List_base::Replace", new_node, new_node.value)
+        if E != nil {
+            return E
+        }
+    }
+    prev, E := p.pred("List_base::Replace", old_node)
+    if E != nil {
+        return E
+    }
+    E = p.modify("List_base::Replace")
+    if E != nil {
+        return E
+    }
+    for c := range p.cursors {
+        if c.node == old_node {
+            c.node = new_node
+        }
+    }
+    p.insertAfter(prev, new_node)
+    p.removeAfter(new_node, old_node)
+    if p.stats != nil {
+        atomic.AddUint64(&p.stats.removals, 1)
+    }
+    // Retire the old value first, so that an equal new value revives it.
+    p.left(old_node, false)
+    p.joining(new_node)
+    p.joined(new_node, Change_insert, prev)
+    return nil
+}   // End of function List_base::Replace.
+
+/*
+List_base::pred() is a private member function for internal use in this
+package.
+It returns the predecessor of the member node q, or nil if q is the first node.
+An error is returned if q is not found in the chain. The argument fn is the name
+of the calling method, for error messages.
+*/
+func (p *List_base) pred(fn string, q *List_node) (*List_node, error) {
+    //----------------------//
+    //    List_base::pred   //
+    //----------------------//
+    if p.first == q {
+        return nil, nil
+    }
+    for pnode := p.first; pnode != nil; pnode = pnode.next {
+        if pnode.next == q {
+            return pnode, nil
+        }
+    }
+    // The node claims to be in the list, but it isn't. Should never happen!
+    return nil, p.fail(ErrNotMember, q, fn + ": node not found")
+}   // End of function List_base::pred.
+
+/*
+List_base::MoveToFront() moves the member node q to the front of the list.
+This is the recency update of a self-organizing list or an LRU list. It costs
+O(n), because the predecessor of q must be found.
+*/
+func (p *List_base) MoveToFront(q *List_node) error {
+    //--------------------------//
+    //  List_base::MoveToFront  //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::MoveToFront: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::MoveToFront", time.Now())
+    }
+    return p.move("List_base::MoveToFront", q, nil, true)
+}   // End of function List_base::MoveToFront.
+
+/*
+List_base::MoveToBack() moves the member node q to the back of the list. It
+costs O(n), because the predecessor of q must be found.
+*/
+func (p *List_base) MoveToBack(q *List_node) error {
+    //--------------------------//
+    //  List_base::MoveToBack   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::MoveToBack: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::MoveToBack", time.Now())
+    }
+    return p.move("List_base::MoveToBack", q, p.last, false)
+}   // End of function List_base::MoveToBack.
+
+/*
+List_base::MoveAfter() moves the member node q to the position after the member
+node mark. It costs O(n), because the predecessor of q must be found.
+*/
+func (p *List_base) MoveAfter(q, mark *List_node) error {
+    //--------------------------//
+    //   List_base::MoveAfter   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::MoveAfter: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::MoveAfter", time.Now())
+    }
+    if mark == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::MoveAfter: mark == nil")
+    }
+    return p.move("List_base::MoveAfter", q, mark, false)
+}   // End of function List_base::MoveAfter.
+
+/*
+List_base::MoveBefore() moves the member node q to the position before the
+member node mark. It costs O(n), because the predecessors of q and mark must be
+found.
+*/
+func (p *List_base) MoveBefore(q, mark *List_node) error {
+    //--------------------------//
+    //  List_base::MoveBefore   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::MoveBefore: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::MoveBefore", time.Now())
+    }
+    if mark == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::MoveBefore: mark == nil")
+    }
+    return p.move("List_base::MoveBefore", q, mark, true)
+}   // End of function List_base::MoveBefore.
+
+/*
+List_base::move() is a private member function for internal use in this
+package.
+It moves the member node q next to the member node mark: before mark if before
+is true, otherwise after mark. If mark is nil and before is true, q is moved to
+the front of the list. The node is relinked, not unlinked, so registered
+cursors stay at it.
+*/
+func (p *List_base) move(fn string, q, mark *List_node, before bool) error {
+    //----------------------//
+    //    List_base::move   //
+    //----------------------//
+    if q == nil {
+        return p.fail(ErrNilArgument, nil, fn + ": q == nil")
+    }
+    if q.base != p {
+        return p.fail(ErrNotMember, q, fn + ": q.base != p")
+    }
+    if mark != nil && mark.base != p {
+        return p.fail(ErrNotMember, mark, fn + ": mark.base != p")
+    }
+    if q == mark {
+        return nil
+    }
+    prev, E := p.pred(fn, q)
+    if E != nil {
+        return E
+    }
+    // Find the node which will precede q.
+    var target *List_node = mark
+    if before {
+        if mark == nil {
+            target = nil
+        } else if mark == q.next {
+            return nil
+        } else {
+            target, E = p.pred(fn, mark)
+            if E != nil {
+                return E
+            }
+        }
+    }
+    if target == prev {
+        return nil
+    }
+    E = p.modify(fn)
+    if E != nil {
+        return E
+    }
+    // Take q out of the chain without unlinking it.
+    if prev == nil {
+        p.first = q.next
+    } else {
+        prev.next = q.next
+    }
+    if p.last == q {
+        p.last = prev
+    }
+    p.insertAfter(target, q)
+    if p.watchers != nil {
+        p.notify(Change_move, q, target)
+    }
+    return nil
+}   // End of function List_base::move.
+
+/*
+List_base::SetAccessMode() sets the self-organizing heuristic which
+List_base::AccessValue() applies.
+*/
+func (p *List_base) SetAccessMode(mode Access_mode) error {
+    //------------------------------//
+    //   List_base::SetAccessMode   //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetAccessMode: p == nil")
+    }
+    if mode < Access_static || mode > Access_transpose {
+        return p.fail(ErrInvalidArgument, nil, "List_base::SetAccessMode: unknown mode")
+    }
+    p.access = mode
+    return nil
+}   // End of function List_base::SetAccessMode.
+
+/*
+List_base::AccessValue() returns the first node whose value satisfies eq, or nil
+if there is no such node. A found node is then moved according to the access
+mode of the list. See List_base::SetAccessMode(). The search and the move take a
+single pass, since the predecessors are tracked during the search.
+*/
+func (p *List_base) AccessValue(eq func(interface{}) bool) (*List_node, error) {
+    //--------------------------//
+    //  List_base::AccessValue  //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::AccessValue: p == nil")
+    }
+    if eq == nil {
+        return nil, p.fail(ErrNilArgument, nil, "List_base::AccessValue: eq == nil")
+    }
+    var pp, prev *List_node // The two predecessors of q.
+    var q *List_node
+    for q = p.first; q != nil; q = q.next {
+        if q.base != p {
+            return nil, p.fail(ErrCorruptList, q, "List_base::AccessValue: q.base != p")
+        }
+        if eq(q.value) {
+            break
+        }
+        pp = prev
+        prev = q
+    }
+    if q == nil || prev == nil || p.access == Access_static {
+        return q, nil
+    }
+    E := p.modify("List_base::AccessValue")
+    if E != nil {
+        return nil, E
+    }
+    // Take q out of the chain without unlinking it.
+    prev.next = q.next
+    if p.last == q {
+        p.last = prev
+    }
+    if p.access == Access_move_to_front {
+        pp = nil
+    }
+    p.insertAfter(pp, q)
+    if p.watchers != nil {
+        p.notify(Change_move, q, pp)
+    }
+    return q, nil
+}   // End of function List_base::AccessValue.
+
+/*
+List_base::PromoteWhere() moves all nodes whose values satisfy pred to the front
+of the list in a single pass. The promoted nodes keep their relative order, and
+so do the other nodes. The nodes are relinked, not removed, so cursors and
+metadata are not affected. The number of matching nodes is returned.
+*/
+func (p *List_base) PromoteWhere(pred func(interface{}) bool) (int, error) {
+    //------------------------------//
+    //   List_base::PromoteWhere    //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::PromoteWhere: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::PromoteWhere", time.Now())
+    }
+    if pred == nil {
+        return 0, p.fail(ErrNilArgument, nil, "List_base::PromoteWhere: pred == nil")
+    }
+    if p.first != nil && p.last == nil {
+        return 0, p.fail(ErrCorruptList, nil, "List_base::PromoteWhere: p.first != p.last == nil")
+    }
+    var n int = 0
+    // Matching nodes at the front of the list stay where they are.
+    var head *List_node = nil
+    q := p.first
+    for ; q != nil; q = q.next {
+        if q.base != p {
+            return n, p.fail(ErrCorruptList, q, "List_base::PromoteWhere: q.base != p")
+        }
+        if !pred(q.value) {
+            break
+        }
+        head = q
+        n += 1
+    }
+    if q == nil {
+        return n, nil
+    }
+    // Collect the other matching nodes in a separate chain.
+    var E error = nil
+    var pfirst, plast *List_node
+    prev := q
+    for r := prev.next; r != nil; r = prev.next {
+        if r.base != p {
+            E = p.fail(ErrCorruptList, r, "List_base::PromoteWhere: r.base != p")
+            break
+        }
+        if !pred(r.value) {
+            prev = r
+            continue
+        }
+        if pfirst == nil {
+            E = p.modify("List_base::PromoteWhere")
+            if E != nil {
+                return n, E
+            }
+        }
+        prev.next = r.next
+        if p.last == r {
+            p.last = prev
+        }
+        r.next = nil
+        if plast == nil {
+            pfirst = r
+        } else {
+            plast.next = r
+        }
+        plast = r
+        n += 1
+    }
+    // Splice the collected chain in after the leading matches.
+    if pfirst != nil {
+        plast.next = q
+        if head == nil {
+            p.first = pfirst
+        } else {
+            head.next = pfirst
+        }
+        if p.watchers != nil {
+            after := head
+            for r := pfirst; ; r = r.next {
+                p.notify(Change_move, r, after)
+                if r == plast {
+                    break
+                }
+                after = r
+            }
+        }
+    }
+    return n, E
+}   // End of function List_base::PromoteWhere.
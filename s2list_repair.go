@@ -0,0 +1,150 @@
+// src/go/s2list_repair.go   2026-10-17
+// Explicit repair of corrupted s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Repair
+List_base::AdoptAll
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+A RepairPolicy selects the repairs which List_base::Repair() may make. The
+values may be combined with the "|" operator.
+    Repair_bases    set nil and wrong base-pointers to the list
+    Repair_cycle    cut the chain where a cycle closes
+    Repair_last     re-derive the last-pointer from the chain
+    Repair_all      all of the above
+*/
+type RepairPolicy int
+
+const (
+    Repair_bases RepairPolicy = 1 << iota
+    Repair_cycle
+    Repair_last
+    Repair_all = Repair_bases | Repair_cycle | Repair_last
+)
+
+/*
+A RepairStats describes the repairs which List_base::Repair() has made.
+    BasesReset int  // Number of base-pointers which were set to the list.
+    CycleCut   bool // True if a cycle was cut.
+    LastFixed  bool // True if the last-pointer was changed.
+    Length     int  // Number of nodes in the list after the repairs.
+*/
+type RepairStats struct {
+    BasesReset int  // Number of base-pointers which were set to the list.
+    CycleCut   bool // True if a cycle was cut.
+    LastFixed  bool // True if the last-pointer was changed.
+    Length     int  // Number of nodes in the list after the repairs.
+}
+
+/*
+List_base::Repair() repairs the structure of a corrupted list, as far as the
+policy allows. A cycle is cut at the node whose next-pointer closes it, so that
+every node remains in the list exactly once. Base-pointers are then set to the
+list, and the last-pointer is re-derived from the chain.
+NOTE: A node with a wrong base-pointer may still be a member of the other list.
+Adopting it with Repair_bases makes it reachable from both lists. Use
+List_base::Validate() first to see which defects there are.
+If the chain has a cycle and the policy does not include Repair_cycle, an error
+is returned and the list is not changed, because the other repairs would not
+terminate. Every repair is logged as for List_base::SetCorruptionPolicy().
+*/
+func (p *List_base) Repair(policy RepairPolicy) (*RepairStats, error) {
+    //----------------------//
+    //   List_base::Repair  //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::Repair: p == nil")
+    }
+    if policy & ^Repair_all != 0 {
+        return nil, p.fail(ErrInvalidArgument, nil, "List_base::Repair: unknown policy")
+    }
+    st := new(RepairStats)
+    start, _ := findCycle(p.first)
+    if start != nil && policy&Repair_cycle == 0 {
+        return nil, p.fail(ErrCorruptList, nil, "List_base::Repair: the chain has a cycle")
+    }
+    // Check the list-base before any copy of a shared chain is made.
+    if p.origin != nil || p.readonly {
+        return nil, p.fail(ErrReadOnly, nil, "List_base::Repair: p is read-only")
+    }
+    if start != nil {
+        q := start
+        for q.next != start {
+            q = q.next
+        }
+        q.next = nil
+        st.CycleCut = true
+        p.report("List_base::Repair: cycle cut")
+    }
+    // Not List_base::modify(), whose debug check would refuse the repair.
+    E := p.writable("List_base::Repair")
+    if E != nil {
+        return nil, E
+    }
+    p.modcount += 1
+    var last *List_node = nil
+    for q := p.first; q != nil; q = q.next {
+        if q.base != p && policy&Repair_bases != 0 {
+            q.base = p
+            st.BasesReset += 1
+        }
+        last = q
+        st.Length += 1
+    }
+    if st.BasesReset > 0 {
+        p.report("List_base::Repair: base-pointers reset")
+    }
+    if p.last != last && policy&Repair_last != 0 {
+        p.last = last
+        st.LastFixed = true
+        p.report("List_base::Repair: last-pointer repaired")
+    }
+    return st, nil
+}   // End of function List_base::Repair.
+
+/*
+List_base::AdoptAll() walks the chain from the first node, sets the
+base-pointer of every node to the list, and sets the last-pointer to the end
+of the chain. The return value is the number of base-pointers which were
+changed. This is the primitive for lists which are built from node chains
+which were linked by other code, and it pairs with List_base::ValidLength(),
+which counts the nil and wrong base-pointers which it fixes.
+Unlike List_base::Repair(), AdoptAll() does not log its changes. If the chain
+has a cycle, an error is returned and the list is not changed.
+*/
+func (p *List_base) AdoptAll() (int, error) {
+    //----------------------//
+    //  List_base::AdoptAll //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::AdoptAll: p == nil")
+    }
+    start, _ := findCycle(p.first)
+    if start != nil {
+        return 0, p.fail(ErrCorruptList, start, "List_base::AdoptAll: the chain has a cycle")
+    }
+    // Not List_base::modify(), whose debug check would refuse the adoption.
+    E := p.writable("List_base::AdoptAll")
+    if E != nil {
+        return 0, E
+    }
+    p.modcount += 1
+    var n int = 0
+    var last *List_node = nil
+    for q := p.first; q != nil; q = q.next {
+        if q.base != p {
+            q.base = p
+            n += 1
+        }
+        last = q
+    }
+    p.last = last
+    return n, nil
+}   // End of function List_base::AdoptAll.
@@ -0,0 +1,713 @@
+// src/go/s2list_replicate.go   2026-10-17
+// Replication of s2list lists to a remote follower by edit scripts.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+ReplicaCaps
+NewReplicator
+Replicator::
+Replicator::Sync
+Replicator::Run
+Replicator::hello
+Replicator::send
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+NewFollower
+Follower::
+Follower::SetMaxMessage
+Follower::Apply
+Follower::admit
+Follower::hello
+Follower::Serve
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+replicaDigest
+writeReplicaMsg
+readReplicaMsg
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "bytes"
+import "encoding/binary"
+import "hash/crc32"
+import "hash/fnv"
+import "io"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+Layout of a replication message. All integers are little-endian.
+    magic       [4]byte "S2RP"
+    version     uint32  the negotiated version, 1 or replica_version
+    flags       uint32  replica_full if the follower must start from empty,
+                        replica_crc if the CRC-32C trailer is present
+    base_hash   uint64  digest of the follower state which the edit applies to
+    new_hash    uint64  digest of the state after the edit
+    prefix      uint32  number of leading values which are kept
+    deleted     uint32  number of values which are removed after the prefix
+    count       uint32  number of records which are inserted after the prefix
+    records     count * (uint32 length, encoded value)
+    trailer     uint32  CRC-32C of the message, if replica_crc is set
+The follower rejects a message whose records, with their length fields, are
+longer than its bound, which is replica_max_msg by default.
+The follower answers with one status byte, replica_ok, replica_resync or
+replica_reject, and the digest of its state as a uint64. A rejected message is
+valid, but the options of the follower list forbid its values.
+Before the first message, the replicator sends a hello, which is a wire header
+with the magic "S2RH" and its capabilities, with the checksum field as a bit
+mask. See Wire_caps. The follower answers with a wire header which holds the
+negotiated capabilities, with the chosen checksum algorithm, or with version 0
+if there are none. A follower also accepts version 1 messages without a hello,
+from replicators which predate the negotiation.
+*/
+const (
+    replica_magic   = "S2RP"
+    replica_hello   = "S2RH"
+    replica_version = 2
+    replica_header  = 40
+    replica_full    = 1
+    replica_crc     = 2
+    replica_ok      = 0
+    replica_resync  = 1
+    replica_reject  = 2
+    replica_max_msg = 64 << 20
+)
+
+/*
+A Replicator keeps a remote follower list in step with a local list. Each call
+of Replicator::Sync() takes a snapshot of the local list and compares its
+digest with the digest of the state which was last sent. If they differ, only
+an edit script is sent: the values which replace the part between the common
+prefix and the common suffix of the two states. The values are encoded with a
+Value_codec, and the digests are computed with List_base::Hash() over the
+encoded values, so the two sides agree on them.
+    list   *List_base    // The local list.
+    rw     io.ReadWriter // The connection to the follower.
+    vc     Value_codec   // Encoding of the values.
+    synced [][]byte      // The encoded state which the follower has.
+    hash   uint64        // The digest of the synced state.
+    valid  bool          // True if the follower state is known.
+    caps   *Wire_caps    // The negotiated capabilities, or nil.
+A Replicator must not be used concurrently. The local list may be modified
+between calls of Sync(), but not during them.
+*/
+type Replicator struct {
+    //----------------------//
+    //     Replicator::     //
+    //----------------------//
+    list   *List_base    // The local list.
+    rw     io.ReadWriter // The connection to the follower.
+    vc     Value_codec   // Encoding of the values.
+    synced [][]byte      // The encoded state which the follower has.
+    hash   uint64        // The digest of the synced state.
+    valid  bool          // True if the follower state is known.
+    caps   *Wire_caps    // The negotiated capabilities, or nil.
+}
+
+/*
+A Follower applies the edit scripts of a remote Replicator to a local list.
+    list    *List_base    // The local copy.
+    rw      io.ReadWriter // The connection to the replicator.
+    vc      Value_codec   // Decoding of the values.
+    caps    Wire_caps     // The negotiated capabilities.
+    max_msg int64         // The bound on the records of a message, in bytes.
+*/
+type Follower struct {
+    //----------------------//
+    //      Follower::      //
+    //----------------------//
+    list    *List_base    // The local copy.
+    rw      io.ReadWriter // The connection to the replicator.
+    vc      Value_codec   // Decoding of the values.
+    caps    Wire_caps     // The negotiated capabilities.
+    max_msg int64         // The bound on the records of a message, in bytes.
+}
+
+/*
+A replica_msg is a decoded replication message.
+*/
+type replica_msg struct {
+    version   uint32
+    flags     uint32
+    base_hash uint64
+    new_hash  uint64
+    prefix    uint32
+    deleted   uint32
+    records   [][]byte
+}
+
+/*
+ReplicaCaps() returns the capabilities of this build for the replication
+protocol with the value codec vc.
+*/
+func ReplicaCaps(vc Value_codec) Wire_caps {
+    //----------------------//
+    //      ReplicaCaps     //
+    //----------------------//
+    return Wire_caps{Version: replica_version, MinVersion: 1, Codec: CodecID(vc),
+        Checksums: 1 << Checksum_none | 1 << Checksum_crc32c}
+}   // End of function ReplicaCaps.
+
+/*
+NewReplicator() returns a replicator which sends the state of the list l over
+rw. The first call of Replicator::Sync() sends the complete list.
+*/
+func NewReplicator(l *List_base, rw io.ReadWriter, vc Value_codec) (*Replicator, error) {
+    //----------------------//
+    //     NewReplicator    //
+    //----------------------//
+    if l == nil || rw == nil || vc == nil {
+        return nil, newError(ErrNilArgument, "NewReplicator: l, rw or vc == nil")
+    }
+    return &Replicator{list: l, rw: rw, vc: vc}, nil
+}   // End of function NewReplicator.
+
+/*
+Replicator::Sync() sends the changes of the list since the last call to the
+follower and waits for its answer. The boolean return value is false if there
+was nothing to send. If the follower reports that its state differs from the
+expected state, the complete list is sent instead.
+*/
+func (p *Replicator) Sync() (bool, error) {
+    //----------------------//
+    //   Replicator::Sync   //
+    //----------------------//
+    if p == nil {
+        return false, newError(ErrNilReceiver, "Replicator::Sync: p == nil")
+    }
+    if p.caps == nil {
+        E := p.hello()
+        if E != nil {
+            return false, E
+        }
+    }
+    snap := p.list.Snapshot()
+    var enc [][]byte
+    var it List_iter
+    it.Init(snap)
+    for {
+        q, E := it.Next()
+        if E != nil {
+            return false, pushError(E, "Replicator::Sync: it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        b, E := p.vc.EncodeValue(q.value)
+        if E != nil {
+            return false, pushError(E, "Replicator::Sync: p.vc.EncodeValue()")
+        }
+        enc = append(enc, b)
+    }
+    hash, E := replicaDigest(snap, p.vc)
+    if E != nil {
+        return false, pushError(E, "Replicator::Sync: replicaDigest()")
+    }
+    if p.valid && hash == p.hash {
+        return false, nil
+    }
+    ok, E := p.send(enc, hash, !p.valid)
+    if E != nil {
+        return false, E
+    }
+    if !ok {
+        // The follower has diverged. Start it from empty.
+        ok, E = p.send(enc, hash, true)
+        if E != nil {
+            return false, E
+        }
+        if !ok {
+            return false, newError(ErrCorruptList, "Replicator::Sync: follower refused a full copy")
+        }
+    }
+    return true, nil
+}   // End of function Replicator::Sync.
+
+/*
+Replicator::Run() calls Replicator::Sync() at the given interval until stop is
+closed or an error occurs.
+*/
+func (p *Replicator) Run(interval time.Duration, stop <-chan struct{}) error {
+    //----------------------//
+    //    Replicator::Run   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Replicator::Run: p == nil")
+    }
+    if interval <= 0 {
+        return newError(ErrInvalidArgument, "Replicator::Run: interval <= 0")
+    }
+    tick := time.NewTicker(interval)
+    defer tick.Stop()
+    for {
+        _, E := p.Sync()
+        if E != nil {
+            return pushError(E, "Replicator::Run: p.Sync()")
+        }
+        select {
+        case <-stop:
+            return nil
+        case <-tick.C:
+        }
+    }
+}   // End of function Replicator::Run.
+
+/*
+Replicator::hello() negotiates the capabilities of the connection with the
+follower. A follower which predates the negotiation rejects the hello, and the
+connection fails.
+*/
+func (p *Replicator) hello() error {
+    //----------------------//
+    //   Replicator::hello  //
+    //----------------------//
+    c := ReplicaCaps(p.vc)
+    buf := make([]byte, wire_header)
+    putWireHeader(buf, replica_hello, &wire_hdr{version: c.Version,
+        min_version: c.MinVersion, codec: c.Codec, flags: c.Flags, checksum: c.Checksums})
+    _, E := p.rw.Write(buf)
+    if E != nil {
+        return pushError(E, "Replicator::hello: p.rw.Write()")
+    }
+    _, E = io.ReadFull(p.rw, buf)
+    if E != nil {
+        return pushError(E, "Replicator::hello: io.ReadFull()")
+    }
+    h, E := getWireHeader("Replicator::hello", buf, replica_hello, replica_version)
+    if E != nil {
+        return E
+    }
+    if h.version == 0 || h.version < h.min_version {
+        return newError(ErrBadFormat, "Replicator::hello: no common capabilities")
+    }
+    p.caps = &Wire_caps{Version: h.version, MinVersion: h.min_version, Codec: h.codec,
+        Flags: h.flags, Checksums: 1 << h.checksum}
+    p.valid = false
+    return nil
+}   // End of function Replicator::hello.
+
+/*
+Replicator::send() sends the edit from the synced state to the state enc, or
+the complete state if full is true, and reads the answer of the follower. The
+boolean return value is false if the follower asks for a full copy.
+*/
+func (p *Replicator) send(enc [][]byte, hash uint64, full bool) (bool, error) {
+    //----------------------//
+    //   Replicator::send   //
+    //----------------------//
+    old := p.synced
+    if full {
+        old = nil
+    }
+    // The common prefix and suffix of the two states.
+    var prefix int = 0
+    for prefix < len(old) && prefix < len(enc) && bytes.Equal(old[prefix], enc[prefix]) {
+        prefix += 1
+    }
+    var suffix int = 0
+    for suffix < len(old)-prefix && suffix < len(enc)-prefix &&
+        bytes.Equal(old[len(old)-1-suffix], enc[len(enc)-1-suffix]) {
+        suffix += 1
+    }
+    m := replica_msg{version: uint32(p.caps.Version), base_hash: p.hash, new_hash: hash,
+        prefix: uint32(prefix), deleted: uint32(len(old) - prefix - suffix),
+        records: enc[prefix : len(enc)-suffix]}
+    if full {
+        m.flags = replica_full
+    }
+    if p.caps.Checksums & (1 << Checksum_crc32c) != 0 {
+        m.flags |= replica_crc
+    }
+    E := writeReplicaMsg(p.rw, &m)
+    if E != nil {
+        p.valid = false
+        p.caps = nil
+        return false, pushError(E, "Replicator::send: writeReplicaMsg()")
+    }
+    var ack [9]byte
+    _, E = io.ReadFull(p.rw, ack[:])
+    if E != nil {
+        p.valid = false
+        p.caps = nil
+        return false, pushError(E, "Replicator::send: io.ReadFull(ack)")
+    }
+    if ack[0] == replica_resync {
+        p.valid = false
+        return false, nil
+    }
+    if ack[0] == replica_reject {
+        p.valid = false
+        return false, newError(ErrInvalidArgument, "Replicator::send: follower rejected the values")
+    }
+    if ack[0] != replica_ok || binary.LittleEndian.Uint64(ack[1:]) != hash {
+        p.valid = false
+        return false, newError(ErrBadFormat, "Replicator::send: bad answer from follower")
+    }
+    p.synced = enc
+    p.hash = hash
+    p.valid = true
+    return true, nil
+}   // End of function Replicator::send.
+
+/*
+NewFollower() returns a follower which applies the messages from rw to the
+list l.
+*/
+func NewFollower(l *List_base, rw io.ReadWriter, vc Value_codec) (*Follower, error) {
+    //----------------------//
+    //      NewFollower     //
+    //----------------------//
+    if l == nil || rw == nil || vc == nil {
+        return nil, newError(ErrNilArgument, "NewFollower: l, rw or vc == nil")
+    }
+    return &Follower{list: l, rw: rw, vc: vc, caps: Wire_caps{Version: 1, MinVersion: 1},
+        max_msg: replica_max_msg}, nil
+}   // End of function NewFollower.
+
+/*
+Follower::SetMaxMessage() sets the maximum size of the records of a message, in
+bytes, including their length fields. A longer message fails with ErrBadFormat
+before its records are allocated, so that a broken or hostile stream cannot
+exhaust the memory. The default is 64 MiB.
+*/
+func (p *Follower) SetMaxMessage(n int64) error {
+    //------------------------------//
+    //   Follower::SetMaxMessage    //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Follower::SetMaxMessage: p == nil")
+    }
+    if n <= 0 {
+        return newError(ErrInvalidArgument, "Follower::SetMaxMessage: n <= 0")
+    }
+    p.max_msg = n
+    return nil
+}   // End of function Follower::SetMaxMessage.
+
+/*
+Follower::Apply() reads one message from the replicator, applies it to the list
+and answers. A hello is answered with the negotiated capabilities. If the list
+is not in the state which the edit applies to, the list is not changed, and the
+replicator is asked for a full copy. The values are inserted like those of
+List_base::AppendValue(), so they are checked against the element type and the
+validator of the list. If one of them is rejected, or the edit would make the
+list longer than its length limit, the list is not changed, the replicator is
+told so, and the error is returned. At the end of the input, io.EOF is
+returned.
+*/
+func (p *Follower) Apply() error {
+    //----------------------//
+    //    Follower::Apply   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Follower::Apply: p == nil")
+    }
+    var hdr [replica_header]byte
+    _, E := io.ReadFull(p.rw, hdr[:4])
+    if E == io.EOF {
+        return E
+    }
+    if E != nil {
+        return pushError(E, "Follower::Apply: io.ReadFull(hdr)")
+    }
+    if string(hdr[:4]) == replica_hello {
+        return p.hello(hdr[:4])
+    }
+    m, E := readReplicaMsg(p.rw, &hdr, p.max_msg)
+    if E != nil {
+        return pushError(E, "Follower::Apply: readReplicaMsg()")
+    }
+    if m.version > uint32(p.caps.Version) {
+        return newError(ErrBadFormat, "Follower::Apply: version was not negotiated")
+    }
+    l := p.list
+    var values []interface{}
+    for _, b := range m.records {
+        v, E := p.vc.DecodeValue(b)
+        if E != nil {
+            return pushError(E, "Follower::Apply: p.vc.DecodeValue()")
+        }
+        values = append(values, v)
+    }
+    status := byte(replica_ok)
+    if m.flags&replica_full != 0 {
+        // A full copy starts from empty, so nothing can be kept or removed.
+        if m.prefix != 0 || m.deleted != 0 {
+            return newError(ErrBadFormat, "Follower::Apply: full copy with prefix or deleted values")
+        }
+    } else {
+        hash, E := replicaDigest(l, p.vc)
+        if E != nil {
+            return pushError(E, "Follower::Apply: replicaDigest()")
+        }
+        if hash != m.base_hash || uint64(m.prefix)+uint64(m.deleted) > uint64(l.Length()) {
+            status = replica_resync
+        }
+    }
+    var reject error = nil
+    if status == replica_ok {
+        reject = p.admit(m, values)
+        if reject != nil {
+            status = replica_reject
+        }
+    }
+    if status == replica_ok {
+        if m.flags&replica_full != 0 {
+            E = l.Clear()
+            if E != nil {
+                return pushError(E, "Follower::Apply: l.Clear()")
+            }
+        }
+        E = l.modify("Follower::Apply")
+        if E != nil {
+            return E
+        }
+        prev := l.nth(int(m.prefix) - 1)
+        for i := uint32(0); i < m.deleted; i += 1 {
+            q := l.first
+            if prev != nil {
+                q = prev.next
+            }
+            l.removeAfter(prev, q)
+            l.left(q, false)
+        }
+        for _, v := range values {
+            pnode := l.newNode(v)
+            l.joining(pnode)
+            l.insertAfter(prev, pnode)
+            l.joined(pnode, Change_insert, prev)
+            prev = pnode
+        }
+    }
+    hash, E := replicaDigest(l, p.vc)
+    if E != nil {
+        return pushError(E, "Follower::Apply: replicaDigest()")
+    }
+    var ack [9]byte
+    ack[0] = status
+    binary.LittleEndian.PutUint64(ack[1:], hash)
+    _, E = p.rw.Write(ack[:])
+    if E != nil {
+        return pushError(E, "Follower::Apply: p.rw.Write(ack)")
+    }
+    return reject
+}   // End of function Follower::Apply.
+
+/*
+Follower::admit() is a private member function for internal use in this
+package.
+It checks the values of the message m, which are decoded into values, against
+the element type, the validator and the length limit of the list, before the
+list is changed. The message must fit the list.
+*/
+func (p *Follower) admit(m *replica_msg, values []interface{}) error {
+    //----------------------//
+    //   Follower::admit    //
+    //----------------------//
+    l := p.list
+    if l.elem_type != nil || l.validator != nil {
+        for _, v := range values {
+            E := l.checkValue("Follower::Apply", nil, v)
+            if E != nil {
+                return E
+            }
+        }
+    }
+    if l.max_len > 0 {
+        n := len(values)
+        if m.flags&replica_full == 0 {
+            n += l.Length() - int(m.deleted)
+        }
+        if n > l.max_len {
+            return l.fail(ErrInvalidArgument, nil, "Follower::Apply: the edit exceeds the length limit")
+        }
+    }
+    return nil
+}   // End of function Follower::admit.
+
+/*
+Follower::hello() answers a hello of the replicator, whose first bytes have
+been read into magic, with the negotiated capabilities.
+*/
+func (p *Follower) hello(magic []byte) error {
+    //----------------------//
+    //    Follower::hello   //
+    //----------------------//
+    buf := make([]byte, wire_header)
+    copy(buf, magic)
+    _, E := io.ReadFull(p.rw, buf[len(magic):])
+    if E != nil {
+        return pushError(E, "Follower::hello: io.ReadFull()")
+    }
+    le := binary.LittleEndian
+    remote := Wire_caps{Version: le.Uint16(buf[4:]), MinVersion: le.Uint16(buf[6:]),
+        Codec: le.Uint16(buf[8:]), Flags: le.Uint16(buf[10:]), Checksums: le.Uint16(buf[12:])}
+    c, NE := Negotiate(ReplicaCaps(p.vc), remote)
+    var h wire_hdr
+    if NE == nil {
+        h = wire_hdr{version: c.Version, min_version: c.MinVersion, codec: c.Codec, flags: c.Flags}
+        if c.Checksums & (1 << Checksum_crc32c) != 0 {
+            h.checksum = Checksum_crc32c
+        }
+        p.caps = c
+    }
+    putWireHeader(buf, replica_hello, &h)
+    _, E = p.rw.Write(buf)
+    if E != nil {
+        return pushError(E, "Follower::hello: p.rw.Write()")
+    }
+    if NE != nil {
+        return pushError(NE, "Follower::hello: Negotiate()")
+    }
+    return nil
+}   // End of function Follower::hello.
+
+/*
+Follower::Serve() applies messages until the end of the input. The return value
+is nil at the end of the input.
+*/
+func (p *Follower) Serve() error {
+    //----------------------//
+    //    Follower::Serve   //
+    //----------------------//
+    for {
+        E := p.Apply()
+        if E == io.EOF {
+            return nil
+        }
+        if E != nil {
+            return E
+        }
+    }
+}   // End of function Follower::Serve.
+
+/*
+replicaDigest() is a private function for internal use in this package.
+It returns the digest of the list which both sides of a replication use, which
+is List_base::Hash() with the FNV-1a hash of the encoded values.
+*/
+func replicaDigest(l *List_base, vc Value_codec) (uint64, error) {
+    //----------------------//
+    //     replicaDigest    //
+    //----------------------//
+    var encE error = nil
+    hash, E := l.Hash(func(v interface{}) uint64 {
+        b, E := vc.EncodeValue(v)
+        if E != nil && encE == nil {
+            encE = E
+        }
+        h := fnv.New64a()
+        h.Write(b)
+        return h.Sum64()
+    })
+    if E != nil {
+        return 0, E
+    }
+    if encE != nil {
+        return 0, pushError(encE, "replicaDigest: vc.EncodeValue()")
+    }
+    return hash, nil
+}   // End of function replicaDigest.
+
+/*
+writeReplicaMsg() is a private function for internal use in this package.
+It writes the replication message m to w.
+*/
+func writeReplicaMsg(w io.Writer, m *replica_msg) error {
+    //----------------------//
+    //    writeReplicaMsg   //
+    //----------------------//
+    le := binary.LittleEndian
+    buf := make([]byte, replica_header)
+    copy(buf, replica_magic)
+    le.PutUint32(buf[4:], m.version)
+    le.PutUint32(buf[8:], m.flags)
+    le.PutUint64(buf[12:], m.base_hash)
+    le.PutUint64(buf[20:], m.new_hash)
+    le.PutUint32(buf[28:], m.prefix)
+    le.PutUint32(buf[32:], m.deleted)
+    le.PutUint32(buf[36:], uint32(len(m.records)))
+    for _, b := range m.records {
+        if uint64(len(b)) > 0xffffffff {
+            return newError(ErrInvalidArgument, "writeReplicaMsg: encoded value too long")
+        }
+        var rec [4]byte
+        le.PutUint32(rec[:], uint32(len(b)))
+        buf = append(buf, rec[:]...)
+        buf = append(buf, b...)
+    }
+    if m.flags & replica_crc != 0 {
+        buf = append(buf, wireChecksum(Checksum_crc32c, buf)...)
+    }
+    _, E := w.Write(buf)
+    if E != nil {
+        return pushError(E, "writeReplicaMsg: w.Write()")
+    }
+    return nil
+}   // End of function writeReplicaMsg.
+
+/*
+readReplicaMsg() is a private function for internal use in this package.
+It reads the rest of a replication message from r, whose first four bytes have
+been read into hdr. The records, with their length fields, may have at most max
+bytes, which is checked before each record is allocated.
+*/
+func readReplicaMsg(r io.Reader, hdr *[replica_header]byte, max int64) (*replica_msg, error) {
+    //----------------------//
+    //    readReplicaMsg    //
+    //----------------------//
+    le := binary.LittleEndian
+    _, E := io.ReadFull(r, hdr[4:])
+    if E != nil {
+        return nil, pushError(E, "readReplicaMsg: io.ReadFull(hdr)")
+    }
+    if string(hdr[:4]) != replica_magic {
+        return nil, newError(ErrBadFormat, "readReplicaMsg: bad magic")
+    }
+    version := le.Uint32(hdr[4:])
+    if version < 1 || version > replica_version {
+        return nil, newError(ErrBadFormat, "readReplicaMsg: unknown version")
+    }
+    sum := crc32.New(crc32c_table)
+    sum.Write(hdr[:])
+    m := &replica_msg{version: version, flags: le.Uint32(hdr[8:]), base_hash: le.Uint64(hdr[12:]),
+        new_hash: le.Uint64(hdr[20:]), prefix: le.Uint32(hdr[28:]),
+        deleted: le.Uint32(hdr[32:])}
+    count := le.Uint32(hdr[36:])
+    if int64(count)*4 > max {
+        return nil, newError(ErrBadFormat, "readReplicaMsg: too many records")
+    }
+    left := max - int64(count)*4
+    for i := uint32(0); i < count; i += 1 {
+        var rec [4]byte
+        _, E = io.ReadFull(r, rec[:])
+        if E != nil {
+            return nil, pushError(E, "readReplicaMsg: io.ReadFull(rec)")
+        }
+        n := int64(le.Uint32(rec[:]))
+        if n > left {
+            return nil, newError(ErrBadFormat, "readReplicaMsg: message too long")
+        }
+        left -= n
+        b := make([]byte, n)
+        _, E = io.ReadFull(r, b)
+        if E != nil {
+            return nil, pushError(E, "readReplicaMsg: io.ReadFull(b)")
+        }
+        sum.Write(rec[:])
+        sum.Write(b)
+        m.records = append(m.records, b)
+    }
+    if m.flags & replica_crc != 0 {
+        var trailer [4]byte
+        _, E = io.ReadFull(r, trailer[:])
+        if E != nil {
+            return nil, pushError(E, "readReplicaMsg: io.ReadFull(trailer)")
+        }
+        if le.Uint32(trailer[:]) != sum.Sum32() {
+            return nil, newError(ErrBadFormat, "readReplicaMsg: checksum mismatch")
+        }
+    }
+    return m, nil
+}   // End of function readReplicaMsg.
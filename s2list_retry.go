@@ -0,0 +1,209 @@
+// src/go/s2list_retry.go   2026-10-17
+// Requeueing of nodes for the retry pattern.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::RequeueToBack
+List_base::RequeueFirstToBack
+List_base::SetMaxAttempts
+List_base::appendKeep
+List_base::canKeep
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::RequeueToBack() puts the node q at the back of the list, for the
+"take from the front, fail, push to the back" retry pattern. The node q may
+either be a member of the list, in which case it is relinked to the back, or a
+node which is not in any list, for example a node which was just popped from
+this list, in which case it is appended.
+Unlike List_base::Append(), this keeps the metadata of the node, so that its
+insertion time and sequence number refer to its first insertion. A node without
+metadata is stamped if metadata is enabled.
+If metadata is enabled, the attempt count in the metadata of the node is
+incremented. A node whose attempt count exceeds the maximum which was set by
+List_base::SetMaxAttempts() is moved to the dead-letter list instead, and the
+caller can tell this from the base-pointer of the node. If the dead-letter list
+does not accept the node, because it is frozen or read-only, or its element
+type or validator rejects the value, an error is returned, and the node stays
+where it was, with its attempt count unchanged.
+*/
+func (p *List_base) RequeueToBack(q *List_node) error {
+    //------------------------------//
+    //   List_base::RequeueToBack   //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::RequeueToBack: p == nil")
+    }
+    if q == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::RequeueToBack: q == nil")
+    }
+    if q.base != p && q.base != nil {
+        return p.fail(ErrNodeInOtherList, q, "List_base::RequeueToBack: q is in another list")
+    }
+    E := p.writable("List_base::RequeueToBack")
+    if E != nil {
+        return E
+    }
+    if p.meta_on && q.meta != nil {
+        q.meta.Attempts += 1
+        if p.max_attempts > 0 && q.meta.Attempts > p.max_attempts {
+            E = p.dead_letter.canKeep("List_base::RequeueToBack", q)
+            if E != nil {
+                q.meta.Attempts -= 1
+                return E
+            }
+            if q.base != p {
+                return p.dead_letter.appendKeep("List_base::RequeueToBack", q)
+            }
+            prev, E := p.pred("List_base::RequeueToBack", q)
+            if E != nil {
+                q.meta.Attempts -= 1
+                return E
+            }
+            E = p.modify("List_base::RequeueToBack")
+            if E != nil {
+                q.meta.Attempts -= 1
+                return E
+            }
+            p.removeAfter(prev, q)
+            p.reportRemove(q)
+            E = p.dead_letter.appendKeep("List_base::RequeueToBack", q)
+            if E != nil {
+                // Put the node back, so that it is not lost.
+                q.meta.Attempts -= 1
+                p.insertAfter(prev, q)
+                p.joined(q, Change_insert, prev)
+                return E
+            }
+            return nil
+        }
+    }
+    if q.base == p {
+        return p.move("List_base::RequeueToBack", q, p.last, false)
+    }
+    return p.appendKeep("List_base::RequeueToBack", q)
+}   // End of function List_base::RequeueToBack.
+
+/*
+List_base::RequeueFirstToBack() moves the first node of the list to the back and
+returns its value. The boolean return value is false if the list is empty. This
+is the value-level form of List_base::RequeueToBack(), for callers which peek
+at the front value, fail to process it, and want to retry it later.
+*/
+func (p *List_base) RequeueFirstToBack() (interface{}, bool, error) {
+    //----------------------------------//
+    //  List_base::RequeueFirstToBack   //
+    //----------------------------------//
+    if p == nil {
+        return nil, false, newError(ErrNilReceiver, "List_base::RequeueFirstToBack: p == nil")
+    }
+    q := p.first
+    if q == nil {
+        return nil, false, nil
+    }
+    E := p.RequeueToBack(q)
+    if E != nil {
+        return nil, false, pushError(E, "List_base::RequeueFirstToBack: p.RequeueToBack(q)")
+    }
+    return q.value, true, nil
+}   // End of function List_base::RequeueFirstToBack.
+
+/*
+List_base::SetMaxAttempts() sets the maximum number of times a node may be
+requeued by List_base::RequeueToBack() while metadata is enabled. A node which
+would exceed the maximum is appended to the dead-letter list dead instead, with
+its metadata. A maximum of zero or less switches the limit off.
+*/
+func (p *List_base) SetMaxAttempts(max int, dead *List_base) error {
+    //------------------------------//
+    //  List_base::SetMaxAttempts   //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::SetMaxAttempts: p == nil")
+    }
+    if max <= 0 {
+        p.max_attempts = 0
+        p.dead_letter = nil
+        return nil
+    }
+    if dead == nil {
+        return p.fail(ErrNilArgument, nil, "List_base::SetMaxAttempts: dead == nil")
+    }
+    if dead == p {
+        return p.fail(ErrInvalidArgument, nil, "List_base::SetMaxAttempts: dead == p")
+    }
+    p.max_attempts = max
+    p.dead_letter = dead
+    return nil
+}   // End of function List_base::SetMaxAttempts.
+
+/*
+List_base::appendKeep() is a private member function for internal use in this
+package.
+It appends the free node q to the list without replacing its metadata. A node
+without metadata is stamped if metadata is enabled. The argument fn is the name
+of the calling method, for error messages.
+*/
+func (p *List_base) appendKeep(fn string, q *List_node) error {
+    //--------------------------//
+    //  List_base::appendKeep   //
+    //--------------------------//
+    if q.base != nil {
+        return p.fail(ErrNodeInOtherList, q, fn + ": q.base != nil")
+    }
+    if p.first != nil && p.last == nil {
+        return p.fail(ErrCorruptList, nil, fn + ": p.first != p.last == nil")
+    }
+    if p.elem_type != nil || p.validator != nil {
+        E := p.checkValue(fn, q, q.value)
+        if E != nil {
+            return E
+        }
+    }
+    counted := p.lenValid()
+    E := p.modify(fn)
+    if E != nil {
+        return E
+    }
+    if p.meta_on && q.meta == nil {
+        p.stamp(q)
+    }
+    if p.release != nil {
+        p.revive(q)
+    }
+    p.insertAfter(p.last, q)
+    p.joined(q, Change_append, nil)
+    if p.max_len > 0 {
+        return p.trim(fn, counted, true)
+    }
+    return nil
+}   // End of function List_base::appendKeep.
+
+/*
+List_base::canKeep() is a private member function for internal use in this
+package.
+It returns the error which List_base::appendKeep() would return for the value
+of the node q, which may still be in another list, so that the caller can
+check the list before it unlinks q there.
+*/
+func (p *List_base) canKeep(fn string, q *List_node) error {
+    //--------------------------//
+    //    List_base::canKeep    //
+    //--------------------------//
+    if p.first != nil && p.last == nil {
+        return p.fail(ErrCorruptList, nil, fn + ": p.first != p.last == nil")
+    }
+    E := p.writable(fn)
+    if E != nil {
+        return E
+    }
+    if p.elem_type != nil || p.validator != nil {
+        return p.checkValue(fn, q, q.value)
+    }
+    return nil
+}   // End of function List_base::canKeep.
@@ -0,0 +1,333 @@
+// src/go/s2list_rope.go   2026-10-17
+// Ropes of byte chunks which are stored in s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+NewRope
+Rope::
+Rope::Len
+Rope::Append
+Rope::InsertAt
+Rope::DeleteRange
+Rope::ReadAt
+Rope::Bytes
+Rope::Reader
+Rope::locate
+Rope::merge
+concatBytes
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "io"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Rope is a sequence of bytes which is stored in chunks, one chunk in each node
+of a list. Bytes can be inserted and deleted at any offset without moving the
+bytes of the other chunks, which makes a rope suitable for editors and for the
+reassembly of protocol data.
+    chunks *List_base // The chunks, which are non-empty []byte values.
+    n      int        // Total number of bytes.
+    size   int        // Maximum chunk length.
+Chunks which grow beyond the maximum length are split, and neighbouring chunks
+which fit together into one chunk are merged after insertions and deletions.
+Locating an offset costs O(number of chunks). The rope copies the inserted
+bytes, and it never writes into a chunk after it is created, so the slices
+which are returned by Rope::Reader() remain valid.
+*/
+type Rope struct {
+    //----------------------//
+    //        Rope::        //
+    //----------------------//
+    chunks *List_base // The chunks, which are non-empty []byte values.
+    n      int        // Total number of bytes.
+    size   int        // Maximum chunk length.
+}
+
+/*
+NewRope() returns an empty rope with the given maximum chunk length. A length
+of zero or less selects the default of 4096 bytes.
+*/
+func NewRope(chunk int) *Rope {
+    //----------------------//
+    //        NewRope       //
+    //----------------------//
+    if chunk <= 0 {
+        chunk = 4096
+    }
+    return &Rope{chunks: new(List_base), size: chunk}
+}   // End of function NewRope.
+
+/*
+Rope::Len() returns the number of bytes in the rope.
+*/
+func (p *Rope) Len() int {
+    //----------------------//
+    //       Rope::Len      //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Rope::Len")
+        return 0
+    }
+    return p.n
+}   // End of function Rope::Len.
+
+/*
+Rope::Append() appends a copy of the bytes b to the rope.
+*/
+func (p *Rope) Append(b []byte) error {
+    //----------------------//
+    //     Rope::Append     //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Rope::Append: p == nil")
+    }
+    E := p.InsertAt(p.n, b)
+    if E != nil {
+        return pushError(E, "Rope::Append: p.InsertAt(p.n, b)")
+    }
+    return nil
+}   // End of function Rope::Append.
+
+/*
+Rope::InsertAt() inserts a copy of the bytes b at the byte offset off, which
+must be in the range from 0 to Rope::Len(). A short insertion is merged into
+the chunk at the offset if it fits. Otherwise that chunk is split at the offset,
+and the bytes are inserted in new chunks.
+*/
+func (p *Rope) InsertAt(off int, b []byte) error {
+    //----------------------//
+    //    Rope::InsertAt    //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Rope::InsertAt: p == nil")
+    }
+    if off < 0 || off > p.n {
+        return newError(ErrInvalidArgument, "Rope::InsertAt: off out of range")
+    }
+    if len(b) == 0 {
+        return nil
+    }
+    E := p.chunks.modify("Rope::InsertAt")
+    if E != nil {
+        return E
+    }
+    prev, q, start := p.locate(off)
+    if q != nil && off > start {
+        c := q.value.([]byte)
+        k := off - start
+        if len(c) + len(b) <= p.size {
+            q.value = concatBytes(c[:k], b, c[k:])
+            p.n += len(b)
+            return nil
+        }
+        // Split the chunk at the offset.
+        right := p.chunks.newNode(c[k:])
+        q.value = c[:k]
+        p.chunks.insertAfter(q, right)
+        prev = q
+    } else if prev != nil && len(prev.value.([]byte)) + len(b) <= p.size {
+        prev.value = concatBytes(prev.value.([]byte), b)
+        p.n += len(b)
+        return nil
+    }
+    // Insert the bytes in chunks of at most the maximum length after prev.
+    data := concatBytes(b)
+    last := prev
+    for len(data) > 0 {
+        k := min(len(data), p.size)
+        pnode := p.chunks.newNode(data[:k:k])
+        p.chunks.insertAfter(last, pnode)
+        last = pnode
+        data = data[k:]
+    }
+    p.n += len(b)
+    p.merge(last)
+    if prev != nil {
+        p.merge(prev)
+    }
+    return nil
+}   // End of function Rope::InsertAt.
+
+/*
+Rope::DeleteRange() deletes n bytes, starting at the byte offset off. The
+range must lie inside the rope. Chunks which are covered by the range are
+removed, and the chunks at the ends of the range are trimmed.
+*/
+func (p *Rope) DeleteRange(off, n int) error {
+    //----------------------//
+    //   Rope::DeleteRange  //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Rope::DeleteRange: p == nil")
+    }
+    if off < 0 || n < 0 || off + n > p.n {
+        return newError(ErrInvalidArgument, "Rope::DeleteRange: range out of bounds")
+    }
+    if n == 0 {
+        return nil
+    }
+    E := p.chunks.modify("Rope::DeleteRange")
+    if E != nil {
+        return E
+    }
+    prev, q, start := p.locate(off)
+    end := off + n
+    for q != nil && start < end {
+        c := q.value.([]byte)
+        next := q.next
+        lo := max(off - start, 0)
+        hi := min(end - start, len(c))
+        switch {
+        case lo == 0 && hi == len(c):
+            p.chunks.removeAfter(prev, q)
+        case lo == 0:
+            q.value = c[hi:]
+            prev = q
+        case hi == len(c):
+            q.value = c[:lo]
+            prev = q
+        default:
+            q.value = concatBytes(c[:lo], c[hi:])
+            prev = q
+        }
+        start += len(c)
+        q = next
+    }
+    p.n -= n
+    if prev != nil {
+        p.merge(prev)
+    }
+    return nil
+}   // End of function Rope::DeleteRange.
+
+/*
+Rope::ReadAt() copies the bytes at the byte offset off into b, as io.ReaderAt
+requires. If fewer than len(b) bytes are available, the error is io.EOF.
+*/
+func (p *Rope) ReadAt(b []byte, off int64) (int, error) {
+    //----------------------//
+    //     Rope::ReadAt     //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "Rope::ReadAt: p == nil")
+    }
+    if off < 0 {
+        return 0, newError(ErrInvalidArgument, "Rope::ReadAt: off < 0")
+    }
+    if off >= int64(p.n) {
+        if len(b) == 0 {
+            return 0, nil
+        }
+        return 0, io.EOF
+    }
+    _, q, start := p.locate(int(off))
+    var k int = 0
+    for q != nil && k < len(b) {
+        c := q.value.([]byte)
+        k += copy(b[k:], c[int(off) + k - start:])
+        start += len(c)
+        q = q.next
+    }
+    if k < len(b) {
+        return k, io.EOF
+    }
+    return k, nil
+}   // End of function Rope::ReadAt.
+
+/*
+Rope::Bytes() returns a copy of the contents of the rope in one slice.
+*/
+func (p *Rope) Bytes() []byte {
+    //----------------------//
+    //      Rope::Bytes     //
+    //----------------------//
+    if p == nil {
+        nilReceiver("Rope::Bytes")
+        return nil
+    }
+    b := make([]byte, 0, p.n)
+    for q := p.chunks.first; q != nil; q = q.next {
+        b = append(b, q.value.([]byte)...)
+    }
+    return b
+}   // End of function Rope::Bytes.
+
+/*
+Rope::Reader() returns an io.Reader which reads the contents of the rope
+without copying the chunks. See List_base::Reader(). The rope must not be
+modified while it is read.
+*/
+func (p *Rope) Reader() io.Reader {
+    //----------------------//
+    //     Rope::Reader     //
+    //----------------------//
+    if p == nil {
+        return (*List_base)(nil).Reader()
+    }
+    return p.chunks.Reader()
+}   // End of function Rope::Reader.
+
+/*
+Rope::locate() is a private member function for internal use in this package.
+It returns the chunk q which contains the byte offset off, its predecessor
+prev, and the offset start of its first byte. If off is the length of the
+rope, q is nil, prev is the last chunk and start is off.
+*/
+func (p *Rope) locate(off int) (prev, q *List_node, start int) {
+    //----------------------//
+    //     Rope::locate     //
+    //----------------------//
+    for q = p.chunks.first; q != nil; q = q.next {
+        c := q.value.([]byte)
+        if off < start + len(c) {
+            return prev, q, start
+        }
+        start += len(c)
+        prev = q
+    }
+    return prev, nil, start
+}   // End of function Rope::locate.
+
+/*
+Rope::merge() is a private member function for internal use in this package.
+It merges the chunk q with its successor if they fit into one chunk. The caller
+must have called List_base::modify() on the chunk list.
+*/
+func (p *Rope) merge(q *List_node) {
+    //----------------------//
+    //      Rope::merge     //
+    //----------------------//
+    next := q.next
+    if next == nil {
+        return
+    }
+    a, b := q.value.([]byte), next.value.([]byte)
+    if len(a) + len(b) > p.size {
+        return
+    }
+    q.value = concatBytes(a, b)
+    p.chunks.removeAfter(q, next)
+}   // End of function Rope::merge.
+
+/*
+concatBytes() is a private function for internal use in this package.
+It returns a new slice with the concatenation of the given slices.
+*/
+func concatBytes(parts ...[]byte) []byte {
+    //----------------------//
+    //      concatBytes     //
+    //----------------------//
+    var n int = 0
+    for _, b := range parts {
+        n += len(b)
+    }
+    r := make([]byte, 0, n)
+    for _, b := range parts {
+        r = append(r, b...)
+    }
+    return r
+}   // End of function concatBytes.
@@ -0,0 +1,86 @@
+// src/go/s2list_scan.go   2026-10-17
+// Reading s2list lists from text with bufio.Scanner.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::AppendFromScanner
+List_base::AppendLines
+List_base::appendScanned
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "bufio"
+import "io"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::AppendFromScanner() appends one node to the list for each token of
+the scanner s, with the token as a string value, until the scanner stops. The
+split function of s decides what a token is, such as bufio.ScanWords. A token
+which cannot be appended is skipped, and scanning goes on. The number of
+appended tokens is returned, together with a *Batch_error listing the failed
+tokens by their zero-based index, or nil if all were appended. If the scanner
+fails, its error is the last failure of the batch, with the index of the token
+which could not be read.
+*/
+func (p *List_base) AppendFromScanner(s *bufio.Scanner) (int, error) {
+    //----------------------------------//
+    //   List_base::AppendFromScanner   //
+    //----------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::AppendFromScanner: p == nil")
+    }
+    if s == nil {
+        return 0, p.fail(ErrNilArgument, nil, "List_base::AppendFromScanner: s == nil")
+    }
+    return p.appendScanned(s, "List_base::AppendFromScanner")
+}   // End of function List_base::AppendFromScanner.
+
+/*
+List_base::AppendLines() appends one node to the list for each line of r, with
+the line as a string value without its line ending, like
+List_base::AppendFromScanner() with bufio.ScanLines. The results are the same.
+A line longer than bufio.MaxScanTokenSize fails with bufio.ErrTooLong, and ends
+the reading.
+*/
+func (p *List_base) AppendLines(r io.Reader) (int, error) {
+    //------------------------------//
+    //    List_base::AppendLines    //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::AppendLines: p == nil")
+    }
+    if r == nil {
+        return 0, p.fail(ErrNilArgument, nil, "List_base::AppendLines: r == nil")
+    }
+    return p.appendScanned(bufio.NewScanner(r), "List_base::AppendLines")
+}   // End of function List_base::AppendLines.
+
+/*
+List_base::appendScanned() is a private member function for internal use in
+this package.
+It appends the tokens of s for List_base::AppendFromScanner(), with op as the
+name of the batch operation.
+*/
+func (p *List_base) appendScanned(s *bufio.Scanner, op string) (int, error) {
+    //------------------------------//
+    //   List_base::appendScanned   //
+    //------------------------------//
+    batch := &Batch_error{Op: op}
+    var n, i int = 0, 0
+    for ; s.Scan(); i += 1 {
+        E := p.AppendValue(s.Text())
+        if E != nil {
+            batch.add(i, pushError(E, op + ": p.AppendValue()"))
+            continue
+        }
+        n += 1
+    }
+    if E := s.Err(); E != nil {
+        batch.add(i, pushError(E, op + ": s.Scan()"))
+    }
+    return n, batch.result()
+}   // End of function List_base::appendScanned.
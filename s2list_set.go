@@ -0,0 +1,111 @@
+// src/go/s2list_set.go   2026-10-17
+// Value-based membership tests for s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::ContainsValue
+List_base::AddIfAbsentValue
+List_base::findValue
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::ContainsValue() returns true if the list contains a node whose value
+equals v according to eq. If eq is nil, the values are compared with the
+Equal function which was registered for the type of the value in the list, or
+else with the "==" operator. If a value of the list has the type of v, and "=="
+cannot compare them, as with two []byte values, ErrInvalidArgument is
+returned. This costs O(n).
+*/
+func (p *List_base) ContainsValue(v interface{},
+    eq func(a, b interface{}) bool) (bool, error) {
+    //------------------------------//
+    //   List_base::ContainsValue   //
+    //------------------------------//
+    if p == nil {
+        return false, newError(ErrNilReceiver, "List_base::ContainsValue: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::ContainsValue", time.Now())
+    }
+    q, E := p.findValue("List_base::ContainsValue", v, eq)
+    if E != nil {
+        return false, E
+    }
+    return q != nil, nil
+}   // End of function List_base::ContainsValue.
+
+/*
+List_base::AddIfAbsentValue() appends a new node with the value v to the list
+if the list does not already contain a node whose value equals v according to
+eq. The return value is true if the node was appended. If eq is nil, the values
+are compared as in List_base::ContainsValue(). This costs O(n).
+*/
+func (p *List_base) AddIfAbsentValue(v interface{},
+    eq func(a, b interface{}) bool) (bool, error) {
+    //----------------------------------//
+    //   List_base::AddIfAbsentValue    //
+    //----------------------------------//
+    if p == nil {
+        return false, newError(ErrNilReceiver, "List_base::AddIfAbsentValue: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::AddIfAbsentValue", time.Now())
+    }
+    q, E := p.findValue("List_base::AddIfAbsentValue", v, eq)
+    if E != nil {
+        return false, E
+    }
+    if q != nil {
+        return false, nil
+    }
+    E = p.AppendValue(v)
+    if E != nil {
+        return false, pushError(E, "List_base::AddIfAbsentValue: p.AppendValue(v)")
+    }
+    return true, nil
+}   // End of function List_base::AddIfAbsentValue.
+
+/*
+List_base::findValue() is a private member function for internal use in this
+package.
+It returns the first node whose value equals v according to eq, or nil if there
+is no such node. If eq is nil, tryEqual() is used, and values which it cannot
+compare are an error.
+The argument fn is the name of the calling method, for error messages.
+*/
+func (p *List_base) findValue(fn string, v interface{},
+    eq func(a, b interface{}) bool) (*List_node, error) {
+    //--------------------------//
+    //   List_base::findValue   //
+    //--------------------------//
+    if p.first != nil && p.last == nil {
+        return nil, p.fail(ErrCorruptList, nil, fn + ": p.first != p.last == nil")
+    }
+    for q := p.first; q != nil; q = q.next {
+        if q.base != p {
+            return nil, p.fail(ErrNotMember, q, fn + ": q.base != p")
+        }
+        if eq == nil {
+            same, ok := tryEqual(q.value, v)
+            if !ok {
+                return nil, p.fail(ErrInvalidArgument, q, fn + ": values are not comparable")
+            }
+            if same {
+                return q, nil
+            }
+        } else if eq(q.value, v) {
+            return q, nil
+        }
+        if q == p.last {
+            break
+        }
+    }
+    return nil, nil
+}   // End of function List_base::findValue.
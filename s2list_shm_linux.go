@@ -0,0 +1,481 @@
+// src/go/s2list_shm_linux.go   2026-10-17
+// Experimental bounded queue in a shared memory segment.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+OpenShmList
+ShmList::
+ShmList::Push
+ShmList::Pop
+ShmList::Length
+ShmList::Dropped
+ShmList::CopyTo
+ShmList::Close
+ShmList::discard
+ShmList::header
+ShmList::lock
+ShmList::unlock
+ShmList::write
+ShmList::read
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/binary"
+import "os"
+import "sync"
+import "syscall"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A ShmList is an EXPERIMENTAL bounded FIFO queue of values which lives in a
+memory-mapped file, typically under /dev/shm, so that a producer process and a
+consumer process can share it without sockets. The values are converted to
+bytes by a Value_codec, as in the compact list layout. Access is serialized
+between processes by an flock() on the file, and between goroutines of one
+process by a mutex.
+The behaviour of ShmList::Push() when the segment is full is determined by the
+Overflow_policy which was given to OpenShmList(). The policy belongs to the
+handle, not to the segment, so a producer process chooses its own policy.
+The segment is a header followed by a circular buffer of records. All integers
+are little-endian.
+    offset 0    magic "S2SH"
+    offset 4    segment version (1)
+    offset 8    capacity of the circular buffer in bytes
+    offset 16   offset of the first record in the buffer
+    offset 24   number of bytes used in the buffer
+    offset 32   number of records
+    offset 64   the circular buffer
+Each record is a uint32 length followed by the encoded value.
+*/
+type ShmList struct {
+    //----------------------//
+    //       ShmList::      //
+    //----------------------//
+    mu      sync.Mutex      // Serializes goroutines of this process.
+    file    *os.File        // The file which backs the segment.
+    mem     []byte          // The mapped segment.
+    vc      Value_codec     // Converts values to and from bytes.
+    policy  Overflow_policy // What ShmList::Push() does when full.
+    dropped uint64          // Number of values dropped by this handle.
+}
+
+const (
+    shm_magic   = "S2SH"
+    shm_version = 1
+    shm_header  = 64
+    shm_poll    = time.Millisecond // Polling interval of Overflow_block.
+)
+
+/*
+OpenShmList() opens the shared memory segment at the given path, creating and
+initializing it with a circular buffer of the given size in bytes if the file
+does not exist. If the file exists, the size argument is ignored and the segment
+is checked for consistency.
+The policy determines what ShmList::Push() does when the segment is full.
+*/
+func OpenShmList(path string, size int, vc Value_codec,
+    policy Overflow_policy) (*ShmList, error) {
+    //----------------------//
+    //      OpenShmList     //
+    //----------------------//
+    if vc == nil {
+        return nil, newError(ErrNilArgument, "OpenShmList: vc == nil")
+    }
+    f, E := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+    if E != nil {
+        return nil, pushError(E, "OpenShmList: os.OpenFile(path)")
+    }
+    p := &ShmList{file: f, vc: vc, policy: policy}
+    // Hold the lock while the segment may be initialized.
+    E = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+    if E != nil {
+        f.Close()
+        return nil, pushError(E, "OpenShmList: syscall.Flock()")
+    }
+    defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+    st, E := f.Stat()
+    if E != nil {
+        f.Close()
+        return nil, pushError(E, "OpenShmList: f.Stat()")
+    }
+    fresh := st.Size() == 0
+    if fresh {
+        if size <= 8 {
+            f.Close()
+            return nil, newError(ErrInvalidArgument, "OpenShmList: size too small")
+        }
+        E = f.Truncate(int64(shm_header + size))
+        if E != nil {
+            f.Close()
+            return nil, pushError(E, "OpenShmList: f.Truncate()")
+        }
+        st, E = f.Stat()
+        if E != nil {
+            f.Close()
+            return nil, pushError(E, "OpenShmList: f.Stat()")
+        }
+    }
+    if st.Size() <= shm_header {
+        f.Close()
+        return nil, newError(ErrBadFormat, "OpenShmList: segment too small")
+    }
+    p.mem, E = syscall.Mmap(int(f.Fd()), 0, int(st.Size()),
+        syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+    if E != nil {
+        f.Close()
+        return nil, pushError(E, "OpenShmList: syscall.Mmap()")
+    }
+    le := binary.LittleEndian
+    if fresh {
+        copy(p.mem, shm_magic)
+        le.PutUint32(p.mem[4:], shm_version)
+        le.PutUint64(p.mem[8:], uint64(len(p.mem)-shm_header))
+    }
+    if string(p.mem[:4]) != shm_magic || le.Uint32(p.mem[4:]) != shm_version ||
+        le.Uint64(p.mem[8:]) != uint64(len(p.mem)-shm_header) {
+        p.Close()
+        return nil, newError(ErrCorruptList, "OpenShmList: bad segment header")
+    }
+    return p, nil
+}   // End of function OpenShmList.
+
+/*
+ShmList::Push() appends a value to the end of the queue. If there is not enough
+free space in the segment, the overflow policy of the handle applies:
+    Overflow_reject         an error is returned
+    Overflow_drop_oldest    the oldest values are popped until there is room
+    Overflow_drop_newest    the value is discarded, and nil is returned
+    Overflow_block          Push() polls until a consumer has made room
+Dropped values are counted. See ShmList::Dropped(). A value which can never fit
+into the segment gives an error under every policy.
+*/
+func (p *ShmList) Push(v interface{}) error {
+    //----------------------//
+    //     ShmList::Push    //
+    //----------------------//
+    if p == nil || p.mem == nil {
+        return newError(ErrClosed, "ShmList::Push: p == nil or closed")
+    }
+    b, E := p.vc.EncodeValue(v)
+    if E != nil {
+        return pushError(E, "ShmList::Push: p.vc.EncodeValue(v)")
+    }
+    le := binary.LittleEndian
+    need := uint64(4 + len(b))
+    if need > uint64(len(p.mem)-shm_header) {
+        return newError(ErrInvalidArgument, "ShmList::Push: value is larger than the segment")
+    }
+    E = p.lock()
+    if E != nil {
+        return pushError(E, "ShmList::Push: p.lock()")
+    }
+    defer p.unlock()
+    capacity, head, used, _, E := p.header()
+    if E != nil {
+        return pushError(E, "ShmList::Push: p.header()")
+    }
+    for need > capacity-used {
+        switch p.policy {
+        case Overflow_drop_oldest:
+            E = p.discard()
+            if E != nil {
+                return pushError(E, "ShmList::Push: p.discard()")
+            }
+            p.dropped += 1
+        case Overflow_drop_newest:
+            p.dropped += 1
+            return nil
+        case Overflow_block:
+            // Let the consumers in.
+            p.unlock()
+            time.Sleep(shm_poll)
+            E = p.lock()
+            if E != nil {
+                // The deferred unlock must not run.
+                p.mu.Lock()
+                return pushError(E, "ShmList::Push: p.lock()")
+            }
+        default:
+            return newError(ErrInvalidArgument, "ShmList::Push: segment is full")
+        }
+        // Other processes may have changed the segment.
+        capacity, head, used, _, E = p.header()
+        if E != nil {
+            return pushError(E, "ShmList::Push: p.header()")
+        }
+    }
+    var hdr [4]byte
+    le.PutUint32(hdr[:], uint32(len(b)))
+    at := (head + used) % capacity
+    at = p.write(at, hdr[:])
+    p.write(at, b)
+    le.PutUint64(p.mem[24:], used+need)
+    le.PutUint64(p.mem[32:], le.Uint64(p.mem[32:])+1)
+    return nil
+}   // End of function ShmList::Push.
+
+/*
+ShmList::Pop() removes the first value from the queue and returns it. The
+boolean return value is false if the queue is empty.
+*/
+func (p *ShmList) Pop() (interface{}, bool, error) {
+    //----------------------//
+    //     ShmList::Pop     //
+    //----------------------//
+    if p == nil || p.mem == nil {
+        return nil, false, newError(ErrClosed, "ShmList::Pop: p == nil or closed")
+    }
+    E := p.lock()
+    if E != nil {
+        return nil, false, pushError(E, "ShmList::Pop: p.lock()")
+    }
+    defer p.unlock()
+    capacity, head, used, count, E := p.header()
+    if E != nil {
+        return nil, false, pushError(E, "ShmList::Pop: p.header()")
+    }
+    if count == 0 {
+        return nil, false, nil
+    }
+    le := binary.LittleEndian
+    var hdr [4]byte
+    at := p.read(head, hdr[:])
+    n := uint64(le.Uint32(hdr[:]))
+    if 4+n > used {
+        return nil, false, newError(ErrCorruptList, "ShmList::Pop: corrupt record length")
+    }
+    b := make([]byte, n)
+    at = p.read(at, b)
+    v, E := p.vc.DecodeValue(b)
+    if E != nil {
+        return nil, false, pushError(E, "ShmList::Pop: p.vc.DecodeValue(b)")
+    }
+    le.PutUint64(p.mem[16:], at%capacity)
+    le.PutUint64(p.mem[24:], used-4-n)
+    le.PutUint64(p.mem[32:], count-1)
+    return v, true, nil
+}   // End of function ShmList::Pop.
+
+/*
+ShmList::Length() returns the number of values in the queue.
+*/
+func (p *ShmList) Length() (int, error) {
+    //----------------------//
+    //    ShmList::Length   //
+    //----------------------//
+    if p == nil || p.mem == nil {
+        return 0, newError(ErrClosed, "ShmList::Length: p == nil or closed")
+    }
+    E := p.lock()
+    if E != nil {
+        return 0, pushError(E, "ShmList::Length: p.lock()")
+    }
+    defer p.unlock()
+    return int(binary.LittleEndian.Uint64(p.mem[32:])), nil
+}   // End of function ShmList::Length.
+
+/*
+ShmList::Dropped() returns the number of values which have been dropped by the
+Overflow_drop_oldest or Overflow_drop_newest policy of this handle.
+*/
+func (p *ShmList) Dropped() uint64 {
+    //----------------------//
+    //   ShmList::Dropped   //
+    //----------------------//
+    if p == nil {
+        return 0
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.dropped
+}   // End of function ShmList::Dropped.
+
+/*
+ShmList::CopyTo() appends copies of all values in the queue to the list b,
+without removing them from the queue. The number of appended values is
+returned.
+*/
+func (p *ShmList) CopyTo(b *List_base) (int, error) {
+    //----------------------//
+    //    ShmList::CopyTo   //
+    //----------------------//
+    if p == nil || p.mem == nil {
+        return 0, newError(ErrClosed, "ShmList::CopyTo: p == nil or closed")
+    }
+    E := p.lock()
+    if E != nil {
+        return 0, pushError(E, "ShmList::CopyTo: p.lock()")
+    }
+    defer p.unlock()
+    _, at, used, count, E := p.header()
+    if E != nil {
+        return 0, pushError(E, "ShmList::CopyTo: p.header()")
+    }
+    le := binary.LittleEndian
+    var i uint64
+    for i = 0; i < count; i += 1 {
+        var hdr [4]byte
+        at = p.read(at, hdr[:])
+        n := uint64(le.Uint32(hdr[:]))
+        if 4+n > used {
+            return int(i), newError(ErrCorruptList, "ShmList::CopyTo: corrupt record length")
+        }
+        used -= 4 + n
+        buf := make([]byte, n)
+        at = p.read(at, buf)
+        v, E := p.vc.DecodeValue(buf)
+        if E != nil {
+            return int(i), pushError(E, "ShmList::CopyTo: p.vc.DecodeValue()")
+        }
+        E = b.AppendValue(v)
+        if E != nil {
+            return int(i), pushError(E, "ShmList::CopyTo: b.AppendValue(v)")
+        }
+    }
+    return int(count), nil
+}   // End of function ShmList::CopyTo.
+
+/*
+ShmList::Close() unmaps the segment and closes the file. The segment itself
+remains, for use by other processes.
+*/
+func (p *ShmList) Close() error {
+    //----------------------//
+    //    ShmList::Close    //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "ShmList::Close: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    var E error
+    if p.mem != nil {
+        E = syscall.Munmap(p.mem)
+        p.mem = nil
+    }
+    if p.file != nil {
+        E2 := p.file.Close()
+        if E == nil {
+            E = E2
+        }
+        p.file = nil
+    }
+    if E != nil {
+        return pushError(E, "ShmList::Close")
+    }
+    return nil
+}   // End of function ShmList::Close.
+
+/*
+ShmList::discard() removes the first record from the queue without decoding it.
+The caller must hold the locks.
+*/
+func (p *ShmList) discard() error {
+    //----------------------//
+    //   ShmList::discard   //
+    //----------------------//
+    capacity, head, used, count, E := p.header()
+    if E != nil {
+        return pushError(E, "ShmList::discard: p.header()")
+    }
+    if count == 0 {
+        return newError(ErrCorruptList, "ShmList::discard: queue is empty")
+    }
+    le := binary.LittleEndian
+    var hdr [4]byte
+    p.read(head, hdr[:])
+    n := uint64(le.Uint32(hdr[:]))
+    if 4+n > used {
+        return newError(ErrCorruptList, "ShmList::discard: corrupt record length")
+    }
+    le.PutUint64(p.mem[16:], (head+4+n)%capacity)
+    le.PutUint64(p.mem[24:], used-4-n)
+    le.PutUint64(p.mem[32:], count-1)
+    return nil
+}   // End of function ShmList::discard.
+
+/*
+ShmList::header() is a private member function for internal use in this
+package. It reads the capacity, the offset of the first record, the number of
+used bytes and the number of records from the segment header, and checks them
+against the size of the mapping, since another process may have written
+nonsense into the shared file. The caller must hold the locks.
+*/
+func (p *ShmList) header() (capacity, head, used, count uint64, E error) {
+    //----------------------//
+    //    ShmList::header   //
+    //----------------------//
+    le := binary.LittleEndian
+    capacity = le.Uint64(p.mem[8:])
+    head = le.Uint64(p.mem[16:])
+    used = le.Uint64(p.mem[24:])
+    count = le.Uint64(p.mem[32:])
+    if capacity != uint64(len(p.mem)-shm_header) || head >= capacity ||
+        used > capacity || count > used/4 || (count == 0) != (used == 0) {
+        return 0, 0, 0, 0, newError(ErrCorruptList, "ShmList::header: bad segment header")
+    }
+    return capacity, head, used, count, nil
+}   // End of function ShmList::header.
+
+/*
+ShmList::lock() acquires the goroutine mutex and the cross-process file lock.
+*/
+func (p *ShmList) lock() error {
+    //----------------------//
+    //     ShmList::lock    //
+    //----------------------//
+    p.mu.Lock()
+    E := syscall.Flock(int(p.file.Fd()), syscall.LOCK_EX)
+    if E != nil {
+        p.mu.Unlock()
+        return E
+    }
+    return nil
+}   // End of function ShmList::lock.
+
+/*
+ShmList::unlock() releases the locks which were acquired by ShmList::lock().
+*/
+func (p *ShmList) unlock() {
+    //----------------------//
+    //    ShmList::unlock   //
+    //----------------------//
+    syscall.Flock(int(p.file.Fd()), syscall.LOCK_UN)
+    p.mu.Unlock()
+}   // End of function ShmList::unlock.
+
+/*
+ShmList::write() copies b into the circular buffer at offset at, wrapping around
+at the end, and returns the offset after the copied bytes.
+*/
+func (p *ShmList) write(at uint64, b []byte) uint64 {
+    //----------------------//
+    //    ShmList::write    //
+    //----------------------//
+    buf := p.mem[shm_header:]
+    n := uint64(copy(buf[at:], b))
+    if n < uint64(len(b)) {
+        copy(buf, b[n:])
+    }
+    return (at + uint64(len(b))) % uint64(len(buf))
+}   // End of function ShmList::write.
+
+/*
+ShmList::read() fills b from the circular buffer at offset at, wrapping around
+at the end, and returns the offset after the copied bytes.
+*/
+func (p *ShmList) read(at uint64, b []byte) uint64 {
+    //----------------------//
+    //     ShmList::read    //
+    //----------------------//
+    buf := p.mem[shm_header:]
+    n := uint64(copy(b, buf[at:]))
+    if n < uint64(len(b)) {
+        copy(b[n:], buf)
+    }
+    return (at + uint64(len(b))) % uint64(len(buf))
+}   // End of function ShmList::read.
@@ -0,0 +1,121 @@
+// src/go/s2list_snapshot.go   2026-10-17
+// Read-only snapshots of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Snapshot
+List_base::IsSnapshot
+List_base::writable
+List_base::nth
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::Snapshot() returns an immutable frozen copy of the list.
+The chain of nodes is copied, together with the node values, so taking a
+snapshot costs O(n). The snapshot shares no nodes with the original list. So
+readers may iterate the snapshot with a List_iter in any goroutine, without a
+lock, while the original list is being modified. The values themselves are
+shared, so values which are pointers must not be modified by the writers. All
+mutating methods of the snapshot itself return an error.
+The snapshot is not copy-on-write. Sharing the chain until the first change
+would require the list to move its live nodes to a new chain at that change,
+and the node pointers which callers hold, such as for List_base::Remove(),
+would then refer to the nodes of the snapshot.
+
+NOTE: Snapshot() reads the original list. If writers run in other goroutines,
+the snapshot must be taken under the same lock which protects the writers.
+*/
+func (p *List_base) Snapshot() *List_base {
+    //----------------------//
+    //  List_base::Snapshot //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::Snapshot")
+        return nil
+    }
+    // A snapshot is already immutable. So it is its own snapshot.
+    if p.origin != nil {
+        return p
+    }
+    s := new(List_base)
+    s.origin = p
+    // The "last" field terminates the chain, in case the chain is corrupt.
+    for q := p.first; q != nil; q = q.next {
+        pnode := new(List_node)
+        pnode.base = s
+        pnode.value = q.value
+        if s.last != nil {
+            s.last.next = pnode
+        } else {
+            s.first = pnode
+        }
+        s.last = pnode
+        if q == p.last {
+            break
+        }
+    }
+    return s
+}   // End of function List_base::Snapshot.
+
+/*
+List_base::IsSnapshot() returns true if the list is a read-only snapshot which
+was created by List_base::Snapshot().
+*/
+func (p *List_base) IsSnapshot() bool {
+    //--------------------------//
+    //  List_base::IsSnapshot   //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("List_base::IsSnapshot")
+        return false
+    }
+    return p.origin != nil
+}   // End of function List_base::IsSnapshot.
+
+/*
+List_base::writable() is a private member function for internal use in this
+package.
+It must be called by every method before it modifies the list structure or a
+value in the list. An error is returned if the list is read-only.
+The argument is the name of the calling method, for error messages.
+*/
+func (p *List_base) writable(fn string) error {
+    //--------------------------//
+    //   List_base::writable    //
+    //--------------------------//
+    if p.frozen {
+        return p.fail(ErrFrozen, nil, fn + ": p is frozen")
+    }
+    if p.origin != nil {
+        return p.fail(ErrReadOnly, nil, fn + ": p is a read-only snapshot")
+    }
+    if p.readonly {
+        return p.fail(ErrReadOnly, nil, fn + ": p is read-only")
+    }
+    return nil
+}   // End of function List_base::writable.
+
+/*
+List_base::nth() is a private member function for internal use in this
+package.
+It returns the node at the given zero-based position, or nil if there is no
+such node.
+*/
+func (p *List_base) nth(i int) *List_node {
+    //----------------------//
+    //    List_base::nth    //
+    //----------------------//
+    if i < 0 {
+        return nil
+    }
+    q := p.first
+    for ; q != nil && i > 0; i -= 1 {
+        q = q.next
+    }
+    return q
+}   // End of function List_base::nth.
@@ -0,0 +1,161 @@
+// src/go/s2list_sortadapter.go   2026-10-17
+// Adapter of s2list lists for the standard sorting functions.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Sort_adapter::
+List_base::SortAdapter
+Sort_adapter::Len
+Sort_adapter::Less
+Sort_adapter::Swap
+Sort_adapter::Nodes
+Sort_adapter::Apply
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "sort"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Sort_adapter is an index of the nodes of a list which implements
+sort.Interface, so that the standard sorting functions can order the list:
+    a, E := b.SortAdapter(less)
+    sort.Stable(a)
+    E = a.Apply()
+The sorting functions permute only the index. Sort_adapter::Apply() relinks
+the nodes of the list in the order of the index. The index slice is also
+available for functions like slices.SortFunc() through Sort_adapter::Nodes().
+For small lists this is often faster than List_base::SortExternal(), at the
+cost of one pointer per node.
+    list     *List_base                  // The list.
+    nodes    []*List_node                // The index of the nodes.
+    less     func(a, b interface{}) bool // The ordering of the values.
+    modcount uint64                      // The ModCount() of the index.
+*/
+type Sort_adapter struct {
+    //----------------------//
+    //    Sort_adapter::    //
+    //----------------------//
+    list     *List_base                  // The list.
+    nodes    []*List_node                // The index of the nodes.
+    less     func(a, b interface{}) bool // The ordering of the values.
+    modcount uint64                      // The ModCount() of the index.
+}
+
+// A Sort_adapter is a sort.Interface.
+var _ sort.Interface = (*Sort_adapter)(nil)
+
+/*
+List_base::SortAdapter() returns a sort adapter for the list, with an index of
+its nodes in their current order. If less is nil, the adapter orders the nodes
+with the Less function which was registered for the type of the first value,
+and an error is returned if that type has none. The list must not be modified
+until Sort_adapter::Apply() has been called. This costs O(n) time and space.
+*/
+func (p *List_base) SortAdapter(less func(a, b interface{}) bool) (*Sort_adapter, error) {
+    //------------------------------//
+    //    List_base::SortAdapter    //
+    //------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::SortAdapter: p == nil")
+    }
+    E := p.writable("List_base::SortAdapter")
+    if E != nil {
+        return nil, E
+    }
+    a := &Sort_adapter{list: p, less: less, modcount: p.modcount}
+    for q := p.first; q != nil; q = q.next {
+        if q.base != p {
+            return nil, p.fail(ErrCorruptList, q, "List_base::SortAdapter: q.base != p")
+        }
+        a.nodes = append(a.nodes, q)
+    }
+    if len(a.nodes) > 0 {
+        if a.nodes[len(a.nodes) - 1] != p.last {
+            return nil, p.fail(ErrCorruptList, nil, "List_base::SortAdapter: p.last is not the last node")
+        }
+        a.less, E = resolveLess("List_base::SortAdapter", less, p.first.value)
+        if E != nil {
+            return nil, E
+        }
+    }
+    return a, nil
+}   // End of function List_base::SortAdapter.
+
+/*
+Sort_adapter::Len() returns the number of nodes in the index.
+*/
+func (p *Sort_adapter) Len() int {
+    //----------------------//
+    //   Sort_adapter::Len  //
+    //----------------------//
+    return len(p.nodes)
+}   // End of function Sort_adapter::Len.
+
+/*
+Sort_adapter::Less() compares the values of the nodes at the positions i and j
+of the index.
+*/
+func (p *Sort_adapter) Less(i, j int) bool {
+    //----------------------//
+    //  Sort_adapter::Less  //
+    //----------------------//
+    return p.less(p.nodes[i].value, p.nodes[j].value)
+}   // End of function Sort_adapter::Less.
+
+/*
+Sort_adapter::Swap() exchanges the nodes at the positions i and j of the
+index. The list is not changed.
+*/
+func (p *Sort_adapter) Swap(i, j int) {
+    //----------------------//
+    //  Sort_adapter::Swap  //
+    //----------------------//
+    p.nodes[i], p.nodes[j] = p.nodes[j], p.nodes[i]
+}   // End of function Sort_adapter::Swap.
+
+/*
+Sort_adapter::Nodes() returns the index of the nodes. It may be permuted, for
+example with slices.SortFunc(), but its elements must not be replaced.
+*/
+func (p *Sort_adapter) Nodes() []*List_node {
+    //----------------------//
+    //  Sort_adapter::Nodes //
+    //----------------------//
+    return p.nodes
+}   // End of function Sort_adapter::Nodes.
+
+/*
+Sort_adapter::Apply() relinks the nodes of the list in the order of the index.
+ErrConcurrentModification is returned if the list was modified after the index
+was made, and the list is not changed. The adapter remains usable, so the list
+can be sorted again with the same index.
+*/
+func (p *Sort_adapter) Apply() error {
+    //----------------------//
+    //  Sort_adapter::Apply //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "Sort_adapter::Apply: p == nil")
+    }
+    b := p.list
+    if b.modcount != p.modcount {
+        return ErrConcurrentModification
+    }
+    if len(p.nodes) == 0 {
+        return nil
+    }
+    E := b.modify("Sort_adapter::Apply")
+    if E != nil {
+        return E
+    }
+    b.takeChain()
+    for _, q := range p.nodes {
+        b.putChain(q, q)
+    }
+    p.modcount = b.modcount
+    return nil
+}   // End of function Sort_adapter::Apply.
@@ -0,0 +1,199 @@
+// src/go/s2list_split.go   2026-10-17
+// Splitting s2list lists: Truncate, TakeInto, Drop and Chunks.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::Truncate
+List_base::TakeInto
+List_base::Drop
+List_base::Chunks
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+List_base::Truncate() keeps the first n nodes of the list and removes all nodes
+after them. The removed nodes are cast adrift, as in List_base::Clear(). The
+number of removed nodes is returned.
+*/
+func (p *List_base) Truncate(n int) (int, error) {
+    //----------------------//
+    //  List_base::Truncate //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::Truncate: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Truncate", time.Now())
+    }
+    if n < 0 {
+        return 0, p.fail(ErrInvalidArgument, nil, "List_base::Truncate: n < 0")
+    }
+    if n == 0 {
+        m, E := p.Drop(-1)
+        if E != nil {
+            return m, pushError(E, "List_base::Truncate: p.Drop(-1)")
+        }
+        return m, nil
+    }
+    if p.first == nil {
+        return 0, nil
+    }
+    if p.last == nil {
+        return 0, p.fail(ErrCorruptList, nil, "List_base::Truncate: p.first != p.last == nil")
+    }
+    // Find the new last node.
+    keep := p.nth(n - 1)
+    if keep == nil || keep.next == nil {
+        return 0, nil
+    }
+    E := p.modify("List_base::Truncate")
+    if E != nil {
+        return 0, E
+    }
+    q := keep.next
+    keep.next = nil
+    p.last = keep
+    var m int = 0
+    for q != nil {
+        next := q.next
+        q.unlink()
+        p.left(q, true)
+        q = next
+        m += 1
+    }
+    return m, nil
+}   // End of function List_base::Truncate.
+
+/*
+List_base::TakeInto() moves the first n nodes of the list, or all nodes if there
+are fewer than n, into a new list, which is returned. The nodes keep their order
+and are not copied. Their base-pointers are set to the new list. Cursors which
+are positioned at moved nodes are advanced to the first node which remains in
+the list.
+*/
+func (p *List_base) TakeInto(n int) (*List_base, error) {
+    //----------------------//
+    //  List_base::TakeInto //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::TakeInto: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::TakeInto", time.Now())
+    }
+    if n < 0 {
+        return nil, p.fail(ErrInvalidArgument, nil, "List_base::TakeInto: n < 0")
+    }
+    r := new(List_base)
+    if n == 0 || p.first == nil {
+        return r, nil
+    }
+    if p.last == nil {
+        return nil, p.fail(ErrCorruptList, nil, "List_base::TakeInto: p.first != p.last == nil")
+    }
+    E := p.modify("List_base::TakeInto")
+    if E != nil {
+        return nil, E
+    }
+    first := p.first
+    last := first
+    for i := 1; i < n && last.next != nil; i += 1 {
+        last = last.next
+    }
+    // Move the cursors off the nodes before the chain is cut.
+    if len(p.cursors) > 0 {
+        for q := first; ; q = q.next {
+            p.leaving(q)
+            if q == last {
+                break
+            }
+        }
+    }
+    p.first = last.next
+    if p.first == nil {
+        p.last = nil
+    }
+    if p.watchers != nil || p.hooks != nil {
+        for q := first; ; q = q.next {
+            p.reportRemove(q)
+            if q == last {
+                break
+            }
+        }
+    }
+    r.putChain(first, last)
+    return r, nil
+}   // End of function List_base::TakeInto.
+
+/*
+List_base::Drop() removes the first n nodes of the list, or all nodes if there
+are fewer than n or if n is negative. The removed nodes are cast adrift, as in
+List_base::Clear(). The number of removed nodes is returned.
+*/
+func (p *List_base) Drop(n int) (int, error) {
+    //----------------------//
+    //    List_base::Drop   //
+    //----------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "List_base::Drop: p == nil")
+    }
+    if p.slow != nil {
+        defer p.slow.observe(p, "List_base::Drop", time.Now())
+    }
+    if n == 0 || p.first == nil {
+        return 0, nil
+    }
+    if p.last == nil {
+        return 0, p.fail(ErrCorruptList, nil, "List_base::Drop: p.first != p.last == nil")
+    }
+    E := p.modify("List_base::Drop")
+    if E != nil {
+        return 0, E
+    }
+    var m int = 0
+    for p.first != nil && (n < 0 || m < n) {
+        q := p.first
+        p.first = q.next
+        if p.first == nil {
+            p.last = nil
+        }
+        q.unlink()
+        p.left(q, true)
+        m += 1
+    }
+    return m, nil
+}   // End of function List_base::Drop.
+
+/*
+List_base::Chunks() moves the nodes of the list into consecutive new lists of
+n nodes each, except that the last list may be shorter. The nodes keep their
+order and are not copied. Their base-pointers are set to their new lists. The
+receiver is empty afterwards, and cursors registered with it become invalid.
+An empty list gives an empty slice.
+*/
+func (p *List_base) Chunks(n int) ([]*List_base, error) {
+    //----------------------//
+    //   List_base::Chunks  //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::Chunks: p == nil")
+    }
+    if n <= 0 {
+        return nil, p.fail(ErrInvalidArgument, nil, "List_base::Chunks: n <= 0")
+    }
+    chunks := make([]*List_base, 0)
+    for p.first != nil {
+        c, E := p.TakeInto(n)
+        if E != nil {
+            return chunks, pushError(E, "List_base::Chunks: p.TakeInto(n)")
+        }
+        chunks = append(chunks, c)
+    }
+    return chunks, nil
+}   // End of function List_base::Chunks.
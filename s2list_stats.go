@@ -0,0 +1,173 @@
+// src/go/s2list_stats.go   2026-10-17
+// Operation statistics of s2list lists, with expvar publishing.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+List_base::EnableStats
+List_base::Stats
+List_base::ResetStats
+List_base::PublishStats
+list_stats::snapshot
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "expvar"
+import "sync/atomic"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A List_stats holds the operation counters of a list. See
+List_base::EnableStats().
+    Appends  uint64 // Nodes appended by List_base::Append().
+    Prepends uint64 // Nodes prepended by List_base::Prepend().
+    Pops     uint64 // Nodes popped by List_base::Popfirst() and Poplast().
+    Removals uint64 // Nodes removed by List_base::Remove(), Replace(), etc.
+    Steps    uint64 // Nodes visited by traversals.
+    Errors   uint64 // Detected integrity errors.
+*/
+type List_stats struct {
+    Appends  uint64 // Nodes appended by List_base::Append().
+    Prepends uint64 // Nodes prepended by List_base::Prepend().
+    Pops     uint64 // Nodes popped by List_base::Popfirst() and Poplast().
+    Removals uint64 // Nodes removed by List_base::Remove(), Replace(), etc.
+    Steps    uint64 // Nodes visited by traversals.
+    Errors   uint64 // Detected integrity errors.
+}
+
+/*
+A list_stats holds the counters of a list. They are updated atomically, so
+that they can be read by another goroutine, such as an expvar handler, while
+the list is in use.
+*/
+type list_stats struct {
+    //----------------------//
+    //     list_stats::     //
+    //----------------------//
+    appends  uint64
+    prepends uint64
+    pops     uint64
+    removals uint64
+    steps    uint64
+    errors   uint64
+}
+
+/*
+List_base::EnableStats() switches the counting of operations on or off. The
+counters start at zero when the counting is switched on. While it is off,
+which is the default, the operations cost nothing extra.
+Traversal steps are counted by List_iter::Next(), List_base::Length(), the
+search for the predecessor in List_base::Poplast(), and the building of the
+predecessor index by List_base::Remove(). Integrity errors are the errors of
+kinds ErrCorruptList, ErrNotMember and ErrNodeInOtherList which the methods of
+the list return.
+*/
+func (p *List_base) EnableStats(on bool) error {
+    //--------------------------//
+    //  List_base::EnableStats  //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::EnableStats: p == nil")
+    }
+    if !on {
+        p.stats = nil
+    } else if p.stats == nil {
+        p.stats = new(list_stats)
+    }
+    return nil
+}   // End of function List_base::EnableStats.
+
+/*
+List_base::Stats() returns the current values of the operation counters of the
+list. The counters are zero if the counting is switched off.
+*/
+func (p *List_base) Stats() List_stats {
+    //----------------------//
+    //   List_base::Stats   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::Stats")
+        return List_stats{}
+    }
+    if p.stats == nil {
+        return List_stats{}
+    }
+    return p.stats.snapshot()
+}   // End of function List_base::Stats.
+
+/*
+List_base::ResetStats() sets the operation counters of the list to zero, if
+the counting is switched on.
+*/
+func (p *List_base) ResetStats() error {
+    //--------------------------//
+    //  List_base::ResetStats   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::ResetStats: p == nil")
+    }
+    if p.stats != nil {
+        atomic.StoreUint64(&p.stats.appends, 0)
+        atomic.StoreUint64(&p.stats.prepends, 0)
+        atomic.StoreUint64(&p.stats.pops, 0)
+        atomic.StoreUint64(&p.stats.removals, 0)
+        atomic.StoreUint64(&p.stats.steps, 0)
+        atomic.StoreUint64(&p.stats.errors, 0)
+    }
+    return nil
+}   // End of function List_base::ResetStats.
+
+/*
+List_base::PublishStats() switches the counting of operations on, and publishes
+the counters as an expvar variable with the given name, so that they appear at
+/debug/vars. The variable is a JSON object with the fields of List_stats, and
+the name of the list at the time of the call. If the counting is switched off
+later, the variable keeps its last values, even if the counting is switched on
+again. Since expvar variables cannot be removed, an error is returned if the
+name is already in use.
+*/
+func (p *List_base) PublishStats(name string) error {
+    //--------------------------//
+    //  List_base::PublishStats //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "List_base::PublishStats: p == nil")
+    }
+    if name == "" {
+        return p.fail(ErrInvalidArgument, nil, "List_base::PublishStats: name is empty")
+    }
+    if expvar.Get(name) != nil {
+        return p.fail(ErrInvalidArgument, nil, "List_base::PublishStats: name is in use")
+    }
+    if p.stats == nil {
+        p.stats = new(list_stats)
+    }
+    st := p.stats
+    list_name := p.name
+    expvar.Publish(name, expvar.Func(func() interface{} {
+        return struct {
+            Name string
+            List_stats
+        }{list_name, st.snapshot()}
+    }))
+    return nil
+}   // End of function List_base::PublishStats.
+
+/*
+list_stats::snapshot() reads the counters atomically.
+*/
+func (p *list_stats) snapshot() List_stats {
+    //--------------------------//
+    //   list_stats::snapshot   //
+    //--------------------------//
+    return List_stats{
+        Appends:  atomic.LoadUint64(&p.appends),
+        Prepends: atomic.LoadUint64(&p.prepends),
+        Pops:     atomic.LoadUint64(&p.pops),
+        Removals: atomic.LoadUint64(&p.removals),
+        Steps:    atomic.LoadUint64(&p.steps),
+        Errors:   atomic.LoadUint64(&p.errors),
+    }
+}   // End of function list_stats::snapshot.
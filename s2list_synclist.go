@@ -0,0 +1,326 @@
+// src/go/s2list_synclist.go   2026-10-17
+// Lists which are shared by goroutines, with blocking pops.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+SyncList::
+NewSyncList
+SyncList::Append
+SyncList::AppendValue
+SyncList::Popfirst
+SyncList::PopfirstWait
+SyncList::Len
+SyncList::Close
+SyncList::SwapContents
+SyncList::StealFirstN
+SyncList::wake
+SyncList::order
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "context"
+import "sync"
+import "sync/atomic"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A SyncList is a list which may be used by several goroutines, such as a work
+queue with producers and consumers. Consumers can wait for nodes with
+SyncList::PopfirstWait() instead of polling. The zero value is an empty list.
+    mu     sync.Mutex    // Protects the other fields.
+    list   List_base     // The nodes.
+    ready  chan struct{} // Closed when a node is added or the list is closed.
+    closed bool          // True after SyncList::Close().
+    id     uint64        // The lock order, assigned by SyncList::order().
+*/
+type SyncList struct {
+    //----------------------//
+    //      SyncList::      //
+    //----------------------//
+    mu     sync.Mutex    // Protects the other fields.
+    list   List_base     // The nodes.
+    ready  chan struct{} // Closed when a node is added or the list is closed.
+    closed bool          // True after SyncList::Close().
+    id     uint64        // The lock order, assigned by SyncList::order().
+}
+
+// The last lock order which was assigned to a SyncList.
+var sync_ids uint64
+
+/*
+NewSyncList() returns an empty list which is configured by the options, like
+New(). It panics if an option is invalid.
+*/
+func NewSyncList(opts ...Option) *SyncList {
+    //----------------------//
+    //      NewSyncList     //
+    //----------------------//
+    p := new(SyncList)
+    E := p.list.apply("NewSyncList", opts)
+    if E != nil {
+        panic(E)
+    }
+    return p
+}   // End of function NewSyncList.
+
+/*
+SyncList::Append() appends a node to the list and wakes the waiting consumers.
+ErrClosed is returned if the list has been closed.
+*/
+func (p *SyncList) Append(pnode *List_node) error {
+    //----------------------//
+    //   SyncList::Append   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "SyncList::Append: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return p.list.fail(ErrClosed, pnode, "SyncList::Append: list is closed")
+    }
+    E := p.list.Append(pnode)
+    if E != nil {
+        return pushError(E, "SyncList::Append: p.list.Append(pnode)")
+    }
+    p.wake()
+    return nil
+}   // End of function SyncList::Append.
+
+/*
+SyncList::AppendValue() appends a new node with the value v to the list and
+wakes the waiting consumers.
+*/
+func (p *SyncList) AppendValue(v interface{}) error {
+    //--------------------------//
+    //  SyncList::AppendValue   //
+    //--------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "SyncList::AppendValue: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return p.list.fail(ErrClosed, nil, "SyncList::AppendValue: list is closed")
+    }
+    E := p.list.AppendValue(v)
+    if E != nil {
+        return pushError(E, "SyncList::AppendValue: p.list.AppendValue(v)")
+    }
+    p.wake()
+    return nil
+}   // End of function SyncList::AppendValue.
+
+/*
+SyncList::Popfirst() pops the first node of the list without waiting. If the
+list is empty, the nil node-pointer is returned and the error is nil.
+*/
+func (p *SyncList) Popfirst() (*List_node, error) {
+    //----------------------//
+    //  SyncList::Popfirst  //
+    //----------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "SyncList::Popfirst: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    q, E := p.list.Popfirst()
+    if E != nil {
+        return nil, pushError(E, "SyncList::Popfirst: p.list.Popfirst()")
+    }
+    return q, nil
+}   // End of function SyncList::Popfirst.
+
+/*
+SyncList::PopfirstWait() pops the first node of the list. If the list is empty,
+it blocks until a node is appended, ctx is done, or the list is closed. The
+error is the error of ctx if ctx is done first, and ErrClosed if the list has
+been closed and is empty. The nodes which are in the list when it is closed can
+still be popped.
+*/
+func (p *SyncList) PopfirstWait(ctx context.Context) (*List_node, error) {
+    //------------------------------//
+    //    SyncList::PopfirstWait    //
+    //------------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "SyncList::PopfirstWait: p == nil")
+    }
+    for {
+        p.mu.Lock()
+        q, E := p.list.Popfirst()
+        if E != nil {
+            p.mu.Unlock()
+            return nil, pushError(E, "SyncList::PopfirstWait: p.list.Popfirst()")
+        }
+        if q != nil {
+            p.mu.Unlock()
+            return q, nil
+        }
+        if p.closed {
+            p.mu.Unlock()
+            return nil, p.list.fail(ErrClosed, nil, "SyncList::PopfirstWait: list is closed")
+        }
+        if p.ready == nil {
+            p.ready = make(chan struct{})
+        }
+        ready := p.ready
+        p.mu.Unlock()
+        select {
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        case <-ready:
+        }
+    }
+}   // End of function SyncList::PopfirstWait.
+
+/*
+SyncList::Len() returns the number of nodes in the list.
+*/
+func (p *SyncList) Len() int {
+    //----------------------//
+    //     SyncList::Len    //
+    //----------------------//
+    if p == nil {
+        nilReceiver("SyncList::Len")
+        return 0
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    return p.list.Length()
+}   // End of function SyncList::Len.
+
+/*
+SyncList::Close() closes the list. Later appends fail, and the waiting consumers
+return ErrClosed when the list is empty. Closing a closed list has no effect.
+*/
+func (p *SyncList) Close() error {
+    //----------------------//
+    //    SyncList::Close   //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "SyncList::Close: p == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if !p.closed {
+        p.closed = true
+        p.wake()
+    }
+    return nil
+}   // End of function SyncList::Close.
+
+/*
+SyncList::SwapContents() exchanges the nodes of the list with the nodes of the
+unshared list b while holding the lock, so that a consumer can take all nodes
+which the producers have appended at once, and drain them without locking.
+Usually b is empty, and the list is empty afterwards. If b is not empty, its
+nodes become available to the consumers, and ErrClosed is returned if the list
+has been closed.
+*/
+func (p *SyncList) SwapContents(b *List_base) error {
+    //------------------------------//
+    //    SyncList::SwapContents    //
+    //------------------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "SyncList::SwapContents: p == nil")
+    }
+    if b == nil {
+        return p.list.fail(ErrNilArgument, nil, "SyncList::SwapContents: b == nil")
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed && b.first != nil {
+        return p.list.fail(ErrClosed, nil, "SyncList::SwapContents: list is closed")
+    }
+    E := SwapContents(&p.list, b)
+    if E != nil {
+        return pushError(E, "SyncList::SwapContents: SwapContents()")
+    }
+    if p.list.first != nil {
+        p.wake()
+    }
+    return nil
+}   // End of function SyncList::SwapContents.
+
+/*
+SyncList::StealFirstN() moves up to n nodes from the front of the list to the
+end of the list dst with List_base::StealFirstN(), and returns the number of
+moved nodes. Both locks are held during the move, so no goroutine sees the
+nodes in neither list, and the consumers which are waiting for dst are woken.
+This is the steal of a work-stealing pool, where an idle worker takes part of
+the queue of a busy one. ErrClosed is returned if dst has been closed. The list
+itself may be closed, because its remaining nodes may still be popped.
+*/
+func (p *SyncList) StealFirstN(n int, dst *SyncList) (int, error) {
+    //------------------------------//
+    //    SyncList::StealFirstN     //
+    //------------------------------//
+    if p == nil {
+        return 0, newError(ErrNilReceiver, "SyncList::StealFirstN: p == nil")
+    }
+    if dst == nil {
+        return 0, p.list.fail(ErrNilArgument, nil, "SyncList::StealFirstN: dst == nil")
+    }
+    if dst == p {
+        return 0, p.list.fail(ErrInvalidArgument, nil, "SyncList::StealFirstN: dst == p")
+    }
+    // Lock in a fixed order, so that two opposite steals cannot deadlock.
+    if p.order() < dst.order() {
+        p.mu.Lock()
+        dst.mu.Lock()
+    } else {
+        dst.mu.Lock()
+        p.mu.Lock()
+    }
+    defer p.mu.Unlock()
+    defer dst.mu.Unlock()
+    if dst.closed {
+        return 0, dst.list.fail(ErrClosed, nil, "SyncList::StealFirstN: dst is closed")
+    }
+    k, E := p.list.StealFirstN(n, &dst.list)
+    if k > 0 {
+        dst.wake()
+    }
+    if E != nil {
+        return k, pushError(E, "SyncList::StealFirstN: p.list.StealFirstN()")
+    }
+    return k, nil
+}   // End of function SyncList::StealFirstN.
+
+/*
+SyncList::wake() is a private member function for internal use in this
+package.
+It wakes all goroutines which are waiting in SyncList::PopfirstWait(). The
+caller must hold the lock.
+*/
+func (p *SyncList) wake() {
+    //----------------------//
+    //    SyncList::wake    //
+    //----------------------//
+    if p.ready != nil {
+        close(p.ready)
+        p.ready = nil
+    }
+}   // End of function SyncList::wake.
+
+/*
+SyncList::order() is a private member function for internal use in this
+package.
+It returns the position of the list in the order in which the locks of two
+lists are acquired. The position is assigned at the first call, so that the
+zero value of a SyncList remains usable.
+*/
+func (p *SyncList) order() uint64 {
+    //----------------------//
+    //   SyncList::order    //
+    //----------------------//
+    id := atomic.LoadUint64(&p.id)
+    if id == 0 {
+        atomic.CompareAndSwapUint64(&p.id, 0, atomic.AddUint64(&sync_ids, 1))
+        id = atomic.LoadUint64(&p.id)
+    }
+    return id
+}   // End of function SyncList::order.
@@ -0,0 +1,202 @@
+// src/go/s2list_validate.go   2026-10-17
+// Structural validation of s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Defect_kind::String
+ValidationReport::OK
+List_base::Validate
+List_base::HasCycle
+findCycle
+-------------------------------------------------------------------------*/
+
+package s2list
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Defect_kind is the type of a structural defect which List_base::Validate()
+has found.
+    Defect_first_nil        "first" is nil, but "last" is not
+    Defect_last_nil         "last" is nil, but "first" is not
+    Defect_nil_base         a node has a nil base-pointer
+    Defect_wrong_base       a node has a base-pointer to another list
+    Defect_cycle            the chain contains a cycle, starting at the node
+    Defect_last_unreachable "last" is not reachable from "first"
+    Defect_last_next        the next-pointer of "last" is not nil
+*/
+type Defect_kind int
+
+const (
+    Defect_first_nil Defect_kind = iota
+    Defect_last_nil
+    Defect_nil_base
+    Defect_wrong_base
+    Defect_cycle
+    Defect_last_unreachable
+    Defect_last_next
+)
+
+/*
+Defect_kind::String() returns the name of a defect kind.
+*/
+func (k Defect_kind) String() string {
+    //--------------------------//
+    //  Defect_kind::String     //
+    //--------------------------//
+    switch k {
+    case Defect_first_nil:
+        return "first-nil"
+    case Defect_last_nil:
+        return "last-nil"
+    case Defect_nil_base:
+        return "nil-base"
+    case Defect_wrong_base:
+        return "wrong-base"
+    case Defect_cycle:
+        return "cycle"
+    case Defect_last_unreachable:
+        return "last-unreachable"
+    case Defect_last_next:
+        return "last-next"
+    }
+    return "unknown"
+}   // End of function Defect_kind::String.
+
+/*
+A Defect describes one structural defect of a list.
+    Kind  Defect_kind // The type of the defect.
+    Index int         // Zero-based position of the node, or -1.
+    Node  *List_node  // The offending node, or nil.
+*/
+type Defect struct {
+    Kind  Defect_kind // The type of the defect.
+    Index int         // Zero-based position of the node, or -1.
+    Node  *List_node  // The offending node, or nil.
+}
+
+/*
+A ValidationReport is the result of List_base::Validate().
+    Length  int      // Number of distinct nodes reachable from "first".
+    Defects []Defect // The defects which were found, in chain order.
+*/
+type ValidationReport struct {
+    Length  int      // Number of distinct nodes reachable from "first".
+    Defects []Defect // The defects which were found, in chain order.
+}
+
+/*
+ValidationReport::OK() returns true if no defects were found.
+*/
+func (p *ValidationReport) OK() bool {
+    //------------------------------//
+    //   ValidationReport::OK       //
+    //------------------------------//
+    return p != nil && len(p.Defects) == 0
+}   // End of function ValidationReport::OK.
+
+/*
+List_base::Validate() checks the structure of the list and reports every defect
+which it finds, with the offending node and its position. The checks are:
+consistency of "first" and "last", the base-pointer of every node, the absence
+of cycles (with Floyd's algorithm, so a cyclic chain is detected without extra
+memory), and the reachability of "last" from "first".
+The list is not modified. Unlike List_base::ValidLength(), this shows where the
+problems are. The error return value is only non-nil if p is nil.
+*/
+func (p *List_base) Validate() (*ValidationReport, error) {
+    //--------------------------//
+    //   List_base::Validate    //
+    //--------------------------//
+    if p == nil {
+        return nil, newError(ErrNilReceiver, "List_base::Validate: p == nil")
+    }
+    r := new(ValidationReport)
+    if p.first == nil {
+        if p.last != nil {
+            r.Defects = append(r.Defects, Defect{Defect_first_nil, -1, p.last})
+        }
+        return r, nil
+    }
+    if p.last == nil {
+        r.Defects = append(r.Defects, Defect{Defect_last_nil, -1, nil})
+    }
+    start, _ := findCycle(p.first)
+    var seen_last bool = false
+    var seen_start bool = false
+    for q := p.first; q != nil; q = q.next {
+        if q == start {
+            if seen_start {
+                break
+            }
+            seen_start = true
+            r.Defects = append(r.Defects, Defect{Defect_cycle, r.Length, q})
+        }
+        if q.base == nil {
+            r.Defects = append(r.Defects, Defect{Defect_nil_base, r.Length, q})
+        } else if q.base != p {
+            r.Defects = append(r.Defects, Defect{Defect_wrong_base, r.Length, q})
+        }
+        if q == p.last {
+            seen_last = true
+            if q.next != nil {
+                r.Defects = append(r.Defects, Defect{Defect_last_next, r.Length, q})
+            }
+        }
+        r.Length += 1
+    }
+    if p.last != nil && !seen_last {
+        r.Defects = append(r.Defects, Defect{Defect_last_unreachable, -1, p.last})
+    }
+    return r, nil
+}   // End of function List_base::Validate.
+
+/*
+List_base::HasCycle() returns true if the chain of the list contains a cycle,
+together with the node where the cycle begins. A cycle can only arise from
+manual surgery on nodes, but it makes methods like List_base::Length() loop
+forever, so this should be called before them when the structure is in doubt.
+It uses Floyd's tortoise-and-hare algorithm, which costs O(n) time and O(1)
+memory. The list is not modified.
+*/
+func (p *List_base) HasCycle() (bool, *List_node, error) {
+    //--------------------------//
+    //   List_base::HasCycle    //
+    //--------------------------//
+    if p == nil {
+        return false, nil, newError(ErrNilReceiver, "List_base::HasCycle: p == nil")
+    }
+    start, _ := findCycle(p.first)
+    return start != nil, start, nil
+}   // End of function List_base::HasCycle.
+
+/*
+findCycle() is a private function for internal use in this package.
+It detects a cycle in the chain which starts at first, with Floyd's
+tortoise-and-hare algorithm. It returns the first node of the cycle and its
+zero-based position in the chain, or nil and -1 if the chain ends with a nil
+next-pointer. This costs O(n) time and O(1) memory.
+*/
+func findCycle(first *List_node) (*List_node, int) {
+    //----------------------//
+    //       findCycle      //
+    //----------------------//
+    slow, fast := first, first
+    for fast != nil && fast.next != nil {
+        slow = slow.next
+        fast = fast.next.next
+        if slow == fast {
+            // The distance from first to the start of the cycle equals the
+            // distance from the meeting point to the start, modulo the
+            // length of the cycle.
+            var i int = 0
+            for slow = first; slow != fast; i += 1 {
+                slow = slow.next
+                fast = fast.next
+            }
+            return slow, i
+        }
+    }
+    return nil, -1
+}   // End of function findCycle.
@@ -0,0 +1,302 @@
+// src/go/s2list_watch.go   2026-10-17
+// Change notifications for s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Change_op::String
+List_base::Watch
+List_base::notify
+List_base::notifyMoves
+List_base::NotifyChan
+List_base::filled
+- - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - - -
+watcher::
+watcher::push
+watcher::pump
+watcher::stop
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "sync"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A Change_op is the kind of a change of a list which is reported by
+List_base::Watch().
+    Change_append   a node was appended
+    Change_prepend  a node was prepended
+    Change_remove   a node was removed or popped
+    Change_clear    all nodes were removed
+    Change_insert   a node was inserted after another node, or at the front
+    Change_move     a node was moved after another node, or to the front
+*/
+type Change_op int
+
+const (
+    Change_append Change_op = iota
+    Change_prepend
+    Change_remove
+    Change_clear
+    Change_insert
+    Change_move
+)
+
+/*
+A ChangeEvent describes one change of a watched list.
+    Op    Change_op   // The kind of change.
+    Node  *List_node  // The affected node, or nil for Change_clear.
+    Value interface{} // The value of the node at the time of the change.
+    After *List_node  // For Change_insert and Change_move, the predecessor.
+    Seq   uint64      // The modification count after the change.
+*/
+type ChangeEvent struct {
+    Op    Change_op   // The kind of change.
+    Node  *List_node  // The affected node, or nil for Change_clear.
+    Value interface{} // The value of the node at the time of the change.
+    After *List_node  // For Change_insert and Change_move, the predecessor.
+    Seq   uint64      // The modification count after the change.
+}
+
+/*
+A watcher queues the events for one channel of List_base::Watch(). The queue
+is unbounded, so that a slow reader never blocks the writers of the list and
+never misses an event.
+*/
+type watcher struct {
+    //----------------------//
+    //       watcher::      //
+    //----------------------//
+    mu     sync.Mutex       // Protects the fields below.
+    cond   *sync.Cond       // Signals new events and closing.
+    queue  List_base        // Events which have not been delivered.
+    closed bool             // True after the unsubscribe function was called.
+    done   chan struct{}    // Closed by the unsubscribe function.
+    ch     chan ChangeEvent // The channel of the caller.
+    once   sync.Once        // Guards the closing of done.
+}
+
+/*
+Change_op::String() returns the name of the change.
+*/
+func (p Change_op) String() string {
+    //----------------------//
+    //   Change_op::String  //
+    //----------------------//
+    switch p {
+    case Change_append:
+        return "append"
+    case Change_prepend:
+        return "prepend"
+    case Change_remove:
+        return "remove"
+    case Change_clear:
+        return "clear"
+    case Change_insert:
+        return "insert"
+    case Change_move:
+        return "move"
+    }
+    return "unknown"
+}   // End of function Change_op::String.
+
+/*
+List_base::Watch() returns a channel which delivers an event for every change
+of the nodes of the list or of their order, in the order of the changes,
+together with a function which ends the subscription and closes the channel.
+So a consumer can mirror the list by applying the events in order.
+Appends, prepends, pops, removals and List_base::Clear() have their own
+events. A node which is inserted in the middle of the list, such as by
+List_iter::InsertAfterCurrent(), is a Change_insert after its predecessor.
+List_base::Replace() is a Change_remove of the old node, followed by a
+Change_insert of the new node. Methods which move chains of nodes between
+lists, such as List_base::TransferAllTo(), List_base::StealFirstN() and
+SwapContents(), report a Change_remove for each node which leaves the list,
+and a Change_append for each node which joins it. Methods which relink the
+nodes of the list, such as List_base::MoveToFront(), List_base::Swap() and
+List_base::Rotate(), report a Change_move for each moved node, after its new
+predecessor, in the new order of the list. Methods which rebuild the list, such
+as List_base::SortExternal(), report all nodes as removed and appended again.
+Only repairs of corrupt lists are not reported. See List_base::Repair().
+Events are queued without limit until they are received, so the channel should
+be drained or unsubscribed.
+The unsubscribe function may be called from any goroutine, more than once.
+*/
+func (p *List_base) Watch() (<-chan ChangeEvent, func()) {
+    //----------------------//
+    //   List_base::Watch   //
+    //----------------------//
+    if p == nil {
+        nilReceiver("List_base::Watch")
+        ch := make(chan ChangeEvent)
+        close(ch)
+        return ch, func() {}
+    }
+    w := &watcher{done: make(chan struct{}), ch: make(chan ChangeEvent)}
+    w.cond = sync.NewCond(&w.mu)
+    p.watchers = append(p.watchers, w)
+    go w.pump()
+    return w.ch, w.stop
+}   // End of function List_base::Watch.
+
+/*
+List_base::notify() is a private member function for internal use in this
+package.
+It queues an event for every watcher of the list. The node after is the
+predecessor of q for Change_insert, and nil otherwise. Watchers which have been
+unsubscribed are dropped.
+*/
+func (p *List_base) notify(op Change_op, q *List_node, after *List_node) {
+    //----------------------//
+    //   List_base::notify  //
+    //----------------------//
+    ev := ChangeEvent{Op: op, Node: q, After: after, Seq: p.modcount}
+    if q != nil {
+        ev.Value = q.value
+    }
+    n := 0
+    for _, w := range p.watchers {
+        if w.push(ev) {
+            p.watchers[n] = w
+            n += 1
+        }
+    }
+    for i := n; i < len(p.watchers); i += 1 {
+        p.watchers[i] = nil
+    }
+    p.watchers = p.watchers[:n]
+    if n == 0 {
+        p.watchers = nil
+    }
+}   // End of function List_base::notify.
+
+/*
+List_base::notifyMoves() is a private member function for internal use in this
+package.
+It queues a Change_move event for every node of the list for which moved
+returns true, with its predecessor, in the order of the list. Applied in this
+order, the moves reproduce the order of the list. This costs O(n).
+*/
+func (p *List_base) notifyMoves(moved func(q *List_node) bool) {
+    //------------------------------//
+    //   List_base::notifyMoves     //
+    //------------------------------//
+    var prev *List_node
+    for q := p.first; q != nil; q = q.next {
+        if moved(q) {
+            p.notify(Change_move, q, prev)
+        }
+        prev = q
+    }
+}   // End of function List_base::notifyMoves.
+
+/*
+List_base::NotifyChan() returns a channel which receives a token whenever the
+list changes from empty to non-empty, so that a consumer can wait for the list
+in a select statement together with other channels. If the list is not empty
+when the channel is first requested, it holds a token already. The channel
+buffers one token, and tokens are never blocked on, so a consumer should pop
+until the list is empty after each token. A token may be left over from nodes
+which were popped already, so a consumer must expect to find the list empty.
+Every call returns the same channel.
+NOTE: The list itself must still be protected by a lock if the consumer and the
+producers run in different goroutines. NotifyChan() must be called under the
+same lock.
+*/
+func (p *List_base) NotifyChan() <-chan struct{} {
+    //--------------------------//
+    //  List_base::NotifyChan   //
+    //--------------------------//
+    if p == nil {
+        nilReceiver("List_base::NotifyChan")
+        return nil
+    }
+    if p.ready == nil {
+        p.ready = make(chan struct{}, 1)
+        if p.first != nil {
+            p.filled()
+        }
+    }
+    return p.ready
+}   // End of function List_base::NotifyChan.
+
+/*
+List_base::filled() is a private member function for internal use in this
+package.
+It is called when the list changes from empty to non-empty, while there is a
+channel for List_base::NotifyChan(). It sends a token unless one is pending.
+*/
+func (p *List_base) filled() {
+    //----------------------//
+    //   List_base::filled  //
+    //----------------------//
+    select {
+    case p.ready <- struct{}{}:
+    default:
+    }
+}   // End of function List_base::filled.
+
+/*
+watcher::push() queues an event. The return value is false if the watcher has
+been unsubscribed.
+*/
+func (p *watcher) push(ev ChangeEvent) bool {
+    //----------------------//
+    //     watcher::push    //
+    //----------------------//
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    if p.closed {
+        return false
+    }
+    p.queue.AppendValue(ev)
+    p.cond.Signal()
+    return true
+}   // End of function watcher::push.
+
+/*
+watcher::pump() runs in its own goroutine. It delivers the queued events to the
+channel until the watcher is unsubscribed, and then closes the channel.
+*/
+func (p *watcher) pump() {
+    //----------------------//
+    //     watcher::pump    //
+    //----------------------//
+    defer close(p.ch)
+    for {
+        p.mu.Lock()
+        for p.queue.first == nil && !p.closed {
+            p.cond.Wait()
+        }
+        if p.closed {
+            p.mu.Unlock()
+            return
+        }
+        q, _ := p.queue.Popfirst()
+        p.mu.Unlock()
+        select {
+        case p.ch <- q.value.(ChangeEvent):
+        case <-p.done:
+            return
+        }
+    }
+}   // End of function watcher::pump.
+
+/*
+watcher::stop() is the unsubscribe function of the watcher.
+*/
+func (p *watcher) stop() {
+    //----------------------//
+    //     watcher::stop    //
+    //----------------------//
+    p.once.Do(func() {
+        p.mu.Lock()
+        p.closed = true
+        p.queue.Clear()
+        p.cond.Signal()
+        p.mu.Unlock()
+        close(p.done)
+    })
+}   // End of function watcher::stop.
@@ -0,0 +1,206 @@
+// src/go/s2list_wire.go   2026-10-17
+// Versioned headers and capability negotiation of the s2list wire formats.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+CodecID
+Negotiate
+putWireHeader
+getWireHeader
+wireChecksum
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "encoding/binary"
+import "hash/crc32"
+
+//=============================================================================
+//=============================================================================
+
+/*
+The binary formats of this package, the compact layout and the replication
+protocol, start with a wire header which lets older and newer builds of the
+package exchange data safely. All integers are little-endian.
+    offset 0    magic       [4]byte the format, e.g. "S2LC"
+    offset 4    version     uint16  the format version of the writer
+    offset 6    min_version uint16  the oldest version which can read the data
+    offset 8    codec       uint16  the codec id of the values
+    offset 10   flags       uint16  the optional features which are present
+    offset 12   checksum    uint16  the checksum algorithm of the trailer
+    offset 14   reserved    uint16  zero
+A reader accepts data if its own version is at least min_version, so a newer
+writer can declare which older readers still understand it. In a negotiation
+message, the checksum field is a bit mask of the supported algorithms.
+Version 1 of each format predates the wire header. Its readers reject the
+later versions as an unknown version, and later readers still accept it.
+*/
+const wire_header = 16
+
+/*
+The codec ids identify the value codecs in the wire header, so that a reader
+detects values which were encoded with a different codec. A codec declares its
+id with a CodecID() method. Codec_custom matches any codec.
+*/
+const (
+    Codec_custom uint16 = 0
+    Codec_bytes  uint16 = 1
+    Codec_string uint16 = 2
+)
+
+/*
+The checksum algorithms of the trailer of a wire format.
+    Checksum_none       no trailer
+    Checksum_crc32c     a uint32 CRC-32C of all preceding bytes
+*/
+const (
+    Checksum_none   uint16 = 0
+    Checksum_crc32c uint16 = 1
+)
+
+/*
+The optional features of a wire format, as flags in the wire header.
+    Wire_meta   the node metadata is included. See List_base::EnableMeta().
+*/
+const (
+    Wire_meta uint16 = 1 << iota
+)
+
+/*
+Wire_caps describes the capabilities of one side of an exchange of data in a
+wire format.
+    Version    uint16 // The newest format version which is supported.
+    MinVersion uint16 // The oldest format version which is supported.
+    Codec      uint16 // The codec id of the values.
+    Flags      uint16 // The optional features which are supported.
+    Checksums  uint16 // The supported checksum algorithms, as 1 << algorithm.
+*/
+type Wire_caps struct {
+    Version    uint16 // The newest format version which is supported.
+    MinVersion uint16 // The oldest format version which is supported.
+    Codec      uint16 // The codec id of the values.
+    Flags      uint16 // The optional features which are supported.
+    Checksums  uint16 // The supported checksum algorithms, as 1 << algorithm.
+}
+
+/*
+A wire_hdr is a decoded wire header.
+*/
+type wire_hdr struct {
+    version     uint16
+    min_version uint16
+    codec       uint16
+    flags       uint16
+    checksum    uint16
+}
+
+// The table of the CRC-32C checksum.
+var crc32c_table = crc32.MakeTable(crc32.Castagnoli)
+
+/*
+CodecID() returns the codec id of the value codec vc, if it has a CodecID()
+method, or else Codec_custom.
+*/
+func CodecID(vc Value_codec) uint16 {
+    //----------------------//
+    //        CodecID       //
+    //----------------------//
+    c, ok := vc.(interface{ CodecID() uint16 })
+    if !ok {
+        return Codec_custom
+    }
+    return c.CodecID()
+}   // End of function CodecID.
+
+/*
+Negotiate() returns the capabilities which two sides with the capabilities
+local and remote can both use: the newest common version, the common optional
+features, and the strongest common checksum algorithm, which is the only bit
+of Checksums in the result. An error of kind ErrBadFormat is returned if there
+is no common version, or if the codecs differ and neither is Codec_custom.
+*/
+func Negotiate(local, remote Wire_caps) (Wire_caps, error) {
+    //----------------------//
+    //       Negotiate      //
+    //----------------------//
+    var r Wire_caps
+    r.Version = min(local.Version, remote.Version)
+    r.MinVersion = max(local.MinVersion, remote.MinVersion)
+    if r.Version < r.MinVersion {
+        return r, newError(ErrBadFormat, "Negotiate: no common version")
+    }
+    r.Codec = local.Codec
+    if r.Codec == Codec_custom {
+        r.Codec = remote.Codec
+    } else if remote.Codec != Codec_custom && remote.Codec != local.Codec {
+        return r, newError(ErrBadFormat, "Negotiate: codec mismatch")
+    }
+    r.Flags = local.Flags & remote.Flags
+    common := local.Checksums & remote.Checksums
+    for alg := 15; alg >= 0; alg -= 1 {
+        if common & (1 << alg) != 0 {
+            r.Checksums = 1 << alg
+            break
+        }
+    }
+    return r, nil
+}   // End of function Negotiate.
+
+/*
+putWireHeader() is a private function for internal use in this package.
+It writes the wire header h with the given magic to the first wire_header bytes
+of buf.
+*/
+func putWireHeader(buf []byte, magic string, h *wire_hdr) {
+    //----------------------//
+    //     putWireHeader    //
+    //----------------------//
+    le := binary.LittleEndian
+    copy(buf, magic)
+    le.PutUint16(buf[4:], h.version)
+    le.PutUint16(buf[6:], h.min_version)
+    le.PutUint16(buf[8:], h.codec)
+    le.PutUint16(buf[10:], h.flags)
+    le.PutUint16(buf[12:], h.checksum)
+    le.PutUint16(buf[14:], 0)
+}   // End of function putWireHeader.
+
+/*
+getWireHeader() is a private function for internal use in this package.
+It decodes the wire header at the start of buf, and checks its magic and that
+the reader version is at least its minimum version. The argument fn is the
+name of the calling function, for error messages.
+*/
+func getWireHeader(fn string, buf []byte, magic string, version uint16) (*wire_hdr, error) {
+    //----------------------//
+    //     getWireHeader    //
+    //----------------------//
+    if len(buf) < wire_header || string(buf[:4]) != magic {
+        return nil, newError(ErrBadFormat, fn + ": bad magic")
+    }
+    le := binary.LittleEndian
+    h := &wire_hdr{version: le.Uint16(buf[4:]), min_version: le.Uint16(buf[6:]),
+        codec: le.Uint16(buf[8:]), flags: le.Uint16(buf[10:]),
+        checksum: le.Uint16(buf[12:])}
+    if h.min_version > version {
+        return nil, newError(ErrBadFormat, fn + ": version too new")
+    }
+    return h, nil
+}   // End of function getWireHeader.
+
+/*
+wireChecksum() is a private function for internal use in this package.
+It returns the checksum trailer of data for the algorithm alg, which is empty
+for Checksum_none.
+*/
+func wireChecksum(alg uint16, data []byte) []byte {
+    //----------------------//
+    //     wireChecksum     //
+    //----------------------//
+    if alg != Checksum_crc32c {
+        return nil
+    }
+    var b [4]byte
+    binary.LittleEndian.PutUint32(b[:], crc32.Checksum(data, crc32c_table))
+    return b[:]
+}   // End of function wireChecksum.
@@ -0,0 +1,223 @@
+// src/go/s2list_wsdeque.go   2026-10-17
+// Work-stealing deques of s2list values for goroutine schedulers.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+WSDeque::
+NewWSDeque
+WSDeque::Push
+WSDeque::Pop
+WSDeque::Steal
+WSDeque::Len
+WSDeque::share
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "sync"
+import "sync/atomic"
+
+//=============================================================================
+//=============================================================================
+
+/*
+A WSDeque is a work-stealing deque. One goroutine, the owner, pushes and pops
+values at one end, and other goroutines, the thieves, steal values from the
+other end when they run out of work of their own.
+The values are held in two lists. The owner pushes and pops at the front of a
+private list, newest first, without locking. The thieves steal from a shared
+list under a mutex, oldest first. A thief which finds the shared list empty
+raises a flag, and at its next push the owner moves the older half of its
+private list to the shared list, oldest first. So the owner locks only when
+thieves are hungry or when its private list is empty, and a thief which finds
+no work costs the owner one atomic load. A value which has been pushed is
+either popped by the owner or stolen by one thief, never both. The owner pops
+in LIFO order, and the thieves steal in FIFO order, but since values move
+between the lists in batches, the orders are not strict. Push(), Pop() and
+Len() may only be called by the owner. The zero value is an empty deque.
+    own    List_base  // The private values of the owner, newest first.
+    mu     sync.Mutex // Protects pub.
+    pub    List_base  // The shared values, oldest first.
+    n_pub  int64      // The length of pub, for checks without the lock.
+    hungry int32      // Non-zero if a thief found pub empty.
+*/
+type WSDeque struct {
+    //----------------------//
+    //       WSDeque::      //
+    //----------------------//
+    own    List_base  // The private values of the owner, newest first.
+    mu     sync.Mutex // Protects pub.
+    pub    List_base  // The shared values, oldest first.
+    n_pub  int64      // The length of pub, for checks without the lock.
+    hungry int32      // Non-zero if a thief found pub empty.
+}
+
+/*
+NewWSDeque() returns an empty deque.
+*/
+func NewWSDeque() *WSDeque {
+    //----------------------//
+    //      NewWSDeque      //
+    //----------------------//
+    return new(WSDeque)
+}   // End of function NewWSDeque.
+
+/*
+WSDeque::Push() pushes the value v at the owner's end of the deque. Only the
+owner may call it.
+*/
+func (p *WSDeque) Push(v interface{}) error {
+    //----------------------//
+    //     WSDeque::Push    //
+    //----------------------//
+    if p == nil {
+        return newError(ErrNilReceiver, "WSDeque::Push: p == nil")
+    }
+    E := p.own.PrependValue(v)
+    if E != nil {
+        return pushError(E, "WSDeque::Push: p.own.PrependValue(v)")
+    }
+    if atomic.LoadInt32(&p.hungry) != 0 {
+        E = p.share()
+        if E != nil {
+            return pushError(E, "WSDeque::Push: p.share()")
+        }
+    }
+    return nil
+}   // End of function WSDeque::Push.
+
+/*
+WSDeque::Pop() pops the newest value of the owner, and returns it with true. If
+the owner has no private values left, a shared value is taken back. False is
+returned if the deque is empty. Only the owner may call it.
+*/
+func (p *WSDeque) Pop() (interface{}, bool, error) {
+    //----------------------//
+    //     WSDeque::Pop     //
+    //----------------------//
+    if p == nil {
+        return nil, false, newError(ErrNilReceiver, "WSDeque::Pop: p == nil")
+    }
+    v, ok, E := p.own.PopfirstValue()
+    if E != nil {
+        return nil, false, pushError(E, "WSDeque::Pop: p.own.PopfirstValue()")
+    }
+    if ok || atomic.LoadInt64(&p.n_pub) == 0 {
+        return v, ok, nil
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    v, ok, E = p.pub.PopfirstValue()
+    if E != nil {
+        return nil, false, pushError(E, "WSDeque::Pop: p.pub.PopfirstValue()")
+    }
+    if ok {
+        atomic.AddInt64(&p.n_pub, -1)
+    }
+    return v, ok, nil
+}   // End of function WSDeque::Pop.
+
+/*
+WSDeque::Steal() takes the oldest shared value of the deque, and returns it
+with true. If there is none, false is returned, and the owner is asked to share
+some of its values, so that a later Steal() may succeed. Any goroutine may call
+it.
+*/
+func (p *WSDeque) Steal() (interface{}, bool, error) {
+    //----------------------//
+    //    WSDeque::Steal    //
+    //----------------------//
+    if p == nil {
+        return nil, false, newError(ErrNilReceiver, "WSDeque::Steal: p == nil")
+    }
+    if atomic.LoadInt64(&p.n_pub) == 0 {
+        atomic.StoreInt32(&p.hungry, 1)
+        return nil, false, nil
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    v, ok, E := p.pub.PopfirstValue()
+    if E != nil {
+        return nil, false, pushError(E, "WSDeque::Steal: p.pub.PopfirstValue()")
+    }
+    if !ok {
+        atomic.StoreInt32(&p.hungry, 1)
+        return nil, false, nil
+    }
+    atomic.AddInt64(&p.n_pub, -1)
+    return v, true, nil
+}   // End of function WSDeque::Steal.
+
+/*
+WSDeque::Len() returns the number of values in the deque. Only the owner may
+call it. The result may be outdated as soon as it is returned, because thieves
+may steal values concurrently.
+*/
+func (p *WSDeque) Len() int {
+    //----------------------//
+    //     WSDeque::Len     //
+    //----------------------//
+    if p == nil {
+        nilReceiver("WSDeque::Len")
+        return 0
+    }
+    return p.own.Length() + int(atomic.LoadInt64(&p.n_pub))
+}   // End of function WSDeque::Len.
+
+/*
+WSDeque::share() is a private member function for internal use in this
+package.
+It moves the older half of the private values of the owner to the end of the
+shared list, in reverse order, so that the oldest value is stolen first. It is
+called by the owner. This costs O(n) for n private values.
+*/
+func (p *WSDeque) share() error {
+    //----------------------//
+    //    WSDeque::share    //
+    //----------------------//
+    atomic.StoreInt32(&p.hungry, 0)
+    n := 0
+    for q := p.own.first; q != nil; q = q.next {
+        n += 1
+    }
+    if n == 0 {
+        return nil
+    }
+    // Keep the newest n / 2 values, and cut off the rest.
+    keep := n / 2
+    E := p.own.modify("WSDeque::share")
+    if E != nil {
+        return E
+    }
+    var first *List_node
+    if keep == 0 {
+        first = p.own.first
+        p.own.first = nil
+        p.own.last = nil
+    } else {
+        s := p.own.first
+        for i := 1; i < keep; i += 1 {
+            s = s.next
+        }
+        first = s.next
+        s.next = nil
+        p.own.last = s
+    }
+    // Reverse the cut-off chain, so that it starts with the oldest value.
+    var head, tail *List_node = nil, first
+    for q := first; q != nil; {
+        next := q.next
+        q.next = head
+        head = q
+        q = next
+    }
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    E = p.pub.modify("WSDeque::share")
+    if E != nil {
+        return E
+    }
+    p.pub.putChain(head, tail)
+    atomic.AddInt64(&p.n_pub, int64(n - keep))
+    return nil
+}   // End of function WSDeque::share.
@@ -0,0 +1,166 @@
+// src/go/s2list_zip.go   2026-10-17
+// Pairwise traversal and interleaving of two s2list lists.
+/*-------------------------------------------------------------------------
+Functions in this file.
+
+Zip
+Interleave
+JoinByTime
+-------------------------------------------------------------------------*/
+
+package s2list
+
+import "iter"
+import "time"
+
+//=============================================================================
+//=============================================================================
+
+/*
+Zip() returns a sequence which yields the values of the two lists pairwise, for
+use in a range-over-func loop:
+    for x, y := range s2list.Zip(a, b) { ... }
+The sequence ends when the shorter list ends. It also ends if an integrity error
+or a modification of either list is detected. Use two List_iter iterators if the
+cause of an early end must be known.
+*/
+func Zip(a, b *List_base) iter.Seq2[interface{}, interface{}] {
+    //----------------------//
+    //          Zip         //
+    //----------------------//
+    return func(yield func(interface{}, interface{}) bool) {
+        var ia, ib List_iter
+        ia.Init(a)
+        ib.Init(b)
+        for {
+            qa, E := ia.Next()
+            if E != nil || qa == nil {
+                return
+            }
+            qb, E := ib.Next()
+            if E != nil || qb == nil {
+                return
+            }
+            if !yield(qa.value, qb.value) {
+                return
+            }
+        }
+    }
+}   // End of function Zip.
+
+/*
+Interleave() moves the nodes of the two lists into a new list, taking nodes
+alternately from a and b, starting with a. When one list is exhausted, the rest
+of the other list follows. No nodes are copied. The lists a and b are empty
+afterwards, and cursors registered with them become invalid.
+*/
+func Interleave(a, b *List_base) (*List_base, error) {
+    //----------------------//
+    //      Interleave      //
+    //----------------------//
+    if a == nil || b == nil {
+        return nil, newError(ErrNilArgument, "Interleave: a == nil || b == nil")
+    }
+    if a == b {
+        return nil, newError(ErrInvalidArgument, "Interleave: a == b")
+    }
+    E := a.modify("Interleave")
+    if E != nil {
+        return nil, E
+    }
+    E = b.modify("Interleave")
+    if E != nil {
+        return nil, E
+    }
+    r := new(List_base)
+    qa, _ := a.takeChain()
+    qb, _ := b.takeChain()
+    for qa != nil || qb != nil {
+        if qa != nil {
+            next := qa.next
+            r.putChain(qa, qa)
+            qa = next
+        }
+        if qb != nil {
+            next := qb.next
+            r.putChain(qb, qb)
+            qb = next
+        }
+    }
+    return r, nil
+}   // End of function Interleave.
+
+/*
+JoinByTime() aligns two time-ordered lists. For every pair of values x from a
+and y from b whose timestamps differ by at most window, the value combine(x, y)
+is appended to a new list, which is returned. The pairs are in the order of a,
+and for each x in the order of b. The function ts returns the timestamp of a
+value. Both lists must be in non-decreasing timestamp order, otherwise an error
+is returned. The lists a and b are not changed.
+This costs O(len(a) + len(b) + number of pairs).
+*/
+func JoinByTime(a, b *List_base, ts func(interface{}) time.Time, window time.Duration,
+    combine func(x, y interface{}) interface{}) (*List_base, error) {
+    //----------------------//
+    //      JoinByTime      //
+    //----------------------//
+    if a == nil || b == nil {
+        return nil, newError(ErrNilArgument, "JoinByTime: a == nil || b == nil")
+    }
+    if ts == nil || combine == nil {
+        return nil, newError(ErrNilArgument, "JoinByTime: ts == nil || combine == nil")
+    }
+    if window < 0 {
+        return nil, newError(ErrInvalidArgument, "JoinByTime: window < 0")
+    }
+    // Timestamps of b are computed once.
+    var bv []interface{}
+    var bt []time.Time
+    var it List_iter
+    it.Init(b)
+    for {
+        q, E := it.Next()
+        if E != nil {
+            return nil, pushError(E, "JoinByTime: b: it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        t := ts(q.value)
+        if len(bt) > 0 && t.Before(bt[len(bt)-1]) {
+            return nil, newError(ErrInvalidArgument, "JoinByTime: b is not in time order")
+        }
+        bv = append(bv, q.value)
+        bt = append(bt, t)
+    }
+    r := new(List_base)
+    var j0 int = 0 // The first value of b which may still match.
+    var prev time.Time
+    it.Init(a)
+    for i := 0; ; i += 1 {
+        q, E := it.Next()
+        if E != nil {
+            return nil, pushError(E, "JoinByTime: a: it.Next()")
+        }
+        if q == nil {
+            break
+        }
+        t := ts(q.value)
+        if i > 0 && t.Before(prev) {
+            return nil, newError(ErrInvalidArgument, "JoinByTime: a is not in time order")
+        }
+        prev = t
+        lo := t.Add(-window)
+        hi := t.Add(window)
+        for j0 < len(bt) && bt[j0].Before(lo) {
+            j0 += 1
+        }
+        for j := j0; j < len(bt) && !bt[j].After(hi); j += 1 {
+            E = r.AppendValue(combine(q.value, bv[j]))
+            if E != nil {
+                return nil, pushError(E, "JoinByTime: r.AppendValue()")
+            }
+        }
+    }
+    return r, nil
+}   // End of function JoinByTime.